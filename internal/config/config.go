@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -43,3 +44,15 @@ func LoadKafkaConfig() (cfg *Kafka) {
 	}
 	return cfg
 }
+
+// LoadAdminConfig loads the comma separated emails of the platform admins into struct
+func LoadAdminConfig() (cfg *Admin) {
+	godotenv.Load()
+	cfg = &Admin{}
+	for _, email := range strings.Split(os.Getenv("PLATFORM_ADMINS"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			cfg.Emails = append(cfg.Emails, email)
+		}
+	}
+	return cfg
+}
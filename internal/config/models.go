@@ -21,3 +21,8 @@ type Redis struct {
 type Kafka struct {
 	KafkaBroker string
 }
+
+// Admin is the struct for the platform admin configurations
+type Admin struct {
+	Emails []string
+}
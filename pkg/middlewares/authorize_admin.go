@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"auction-web/internal/logger"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuthorizeAdmin rejects the request unless the caller is one of the platform admins,
+// who manage the data shared by every auction
+func AuthorizeAdmin(admins []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auctionLogger := logger.Get()
+
+		email := c.GetString("email")
+		if email == "" {
+			auctionLogger.Error("failed to fetch email from token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+			return
+		}
+
+		if !slices.Contains(admins, email) {
+			auctionLogger.Warn("caller is not a platform admin", zap.String("email", email))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to perform this operation"})
+			return
+		}
+		c.Next()
+	}
+}
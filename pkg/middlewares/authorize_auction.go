@@ -0,0 +1,220 @@
+package middlewares
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/internal/logger"
+	"auction-web/pkg/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAuctionIDMissing = errors.New("auction id not found in request")
+	ErrMixedAuctions    = errors.New("request spans more than one auction")
+)
+
+// AuctionResolver extracts the auction a request operates on
+type AuctionResolver func(c *gin.Context, db *mongo.Database) (primitive.ObjectID, error)
+
+// AuthorizeAuction resolves the role of the caller in the auction of the request
+// and rejects the request unless it is one of the allowed roles
+func AuthorizeAuction(db *mongo.Database, resolve AuctionResolver, allowed ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auctionLogger := logger.Get()
+
+		email := c.GetString("email")
+		if email == "" {
+			auctionLogger.Error("failed to fetch email from token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+			return
+		}
+
+		auctionID, err := resolve(c, db)
+		if err != nil {
+			auctionLogger.Warn("failed to resolve auction of request", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		role, err := ResolveAuctionRole(ctx, db, auctionID, email)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+				return
+			}
+			auctionLogger.Error("failed to resolve auction role", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+
+		for _, r := range allowed {
+			if r == role {
+				c.Set("auction_id", auctionID)
+				c.Set("auction_role", role)
+				c.Next()
+				return
+			}
+		}
+
+		auctionLogger.Warn("caller not allowed in auction",
+			zap.String("email", email),
+			zap.String("role", role),
+			zap.String("auction_id", auctionID.Hex()))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to perform this operation"})
+	}
+}
+
+// AuthorizedAuction returns the auction the request was authorized for by AuthorizeAuction
+func AuthorizedAuction(c *gin.Context) primitive.ObjectID {
+	value, _ := c.Get("auction_id")
+	auctionID, _ := value.(primitive.ObjectID)
+	return auctionID
+}
+
+// ResolveAuctionRole returns the role of the user in the auction, an empty role means not a member
+func ResolveAuctionRole(ctx context.Context, db *mongo.Database, auctionID primitive.ObjectID, email string) (string, error) {
+	var auction models.Auction
+
+	filter := bson.M{"_id": auctionID, "deleted_at": bson.M{"$exists": false}}
+	if err := db.Collection("auctions").FindOne(ctx, filter).Decode(&auction); err != nil {
+		return "", err
+	}
+
+	if auction.CreatedBy == email {
+		return models.AuctionRoleOwner, nil
+	}
+	for _, admin := range auction.CoAdmins {
+		if admin == email {
+			return models.AuctionRoleCoAdmin, nil
+		}
+	}
+
+	count, err := db.Collection("teams").CountDocuments(ctx, bson.M{
+		"auction_id":  auctionID,
+		"team_owners": email,
+	})
+	if err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return models.AuctionRoleTeamOwner, nil
+	}
+
+	for _, participant := range auction.JoinedBy {
+		if participant == email {
+			return models.AuctionRoleSpectator, nil
+		}
+	}
+
+	// Anyone can watch a public auction without joining it
+	if auction.IsPublic {
+		return models.AuctionRoleSpectator, nil
+	}
+	return "", nil
+}
+
+// AuctionFromBody reads the auction_id of a JSON object, or of every element of a JSON array
+func AuctionFromBody(c *gin.Context, _ *mongo.Database) (primitive.ObjectID, error) {
+	type auctionRef struct {
+		AuctionID primitive.ObjectID `json:"auction_id"`
+	}
+
+	body, err := peekBody(c)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	var single auctionRef
+	if err = json.Unmarshal(body, &single); err == nil {
+		if single.AuctionID.IsZero() {
+			return primitive.NilObjectID, ErrAuctionIDMissing
+		}
+		return single.AuctionID, nil
+	}
+
+	var batch []auctionRef
+	if err = json.Unmarshal(body, &batch); err != nil {
+		return primitive.NilObjectID, err
+	}
+	if len(batch) == 0 || batch[0].AuctionID.IsZero() {
+		return primitive.NilObjectID, ErrAuctionIDMissing
+	}
+	for _, ref := range batch[1:] {
+		if ref.AuctionID != batch[0].AuctionID {
+			return primitive.NilObjectID, ErrMixedAuctions
+		}
+	}
+	return batch[0].AuctionID, nil
+}
+
+// AuctionFromForm reads the auction_id field of a form or multipart request
+func AuctionFromForm(c *gin.Context, _ *mongo.Database) (primitive.ObjectID, error) {
+	return primitive.ObjectIDFromHex(c.PostForm("auction_id"))
+}
+
+// AuctionFromPlayers reads the player_id, single or list, of a JSON body and
+// returns the auction those players belong to
+func AuctionFromPlayers(c *gin.Context, db *mongo.Database) (primitive.ObjectID, error) {
+	body, err := peekBody(c)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	var ids []primitive.ObjectID
+	var single struct {
+		PlayerID primitive.ObjectID `json:"player_id"`
+	}
+	var list struct {
+		PlayerID []primitive.ObjectID `json:"player_id"`
+	}
+	if err = json.Unmarshal(body, &single); err == nil {
+		ids = append(ids, single.PlayerID)
+	} else if err = json.Unmarshal(body, &list); err == nil {
+		ids = list.PlayerID
+	} else {
+		return primitive.NilObjectID, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	auctionIDs, err := db.Collection("players").Distinct(ctx, "auction_id", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if len(auctionIDs) == 0 {
+		return primitive.NilObjectID, ErrAuctionIDMissing
+	}
+	if len(auctionIDs) > 1 {
+		return primitive.NilObjectID, ErrMixedAuctions
+	}
+
+	auctionID, ok := auctionIDs[0].(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, ErrAuctionIDMissing
+	}
+	return auctionID, nil
+}
+
+// peekBody reads the request body and restores it for the handlers down the chain
+func peekBody(c *gin.Context) ([]byte, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Steps of the cascading delete of an auction, in execution order
+const (
+	DeletionStepMatches = iota
+	DeletionStepPlayers
+	DeletionStepTeams
+	DeletionStepInvites
+	DeletionStepScouting
+	DeletionStepFantasy
+	DeletionStepCache
+	DeletionStepAuction
+	DeletionStepDone
+)
+
+// AuctionDeletion tracks a cascading delete so a partial failure can be resumed
+type AuctionDeletion struct {
+	AuctionID primitive.ObjectID `bson:"_id" json:"auction_id"`
+	Members   []string           `bson:"members" json:"members"`
+	Step      int                `bson:"step" json:"step"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// Roles of a user within an auction
+const (
+	AuctionRoleOwner     = "owner"
+	AuctionRoleCoAdmin   = "co_admin"
+	AuctionRoleTeamOwner = "team_owner"
+	AuctionRoleSpectator = "spectator"
+)
+
+var (
+	// AuctionAdminRoles can manage the teams and players of an auction
+	AuctionAdminRoles = []string{AuctionRoleOwner, AuctionRoleCoAdmin}
+
+	// AuctionMemberRoles are every role allowed to view an auction
+	AuctionMemberRoles = []string{AuctionRoleOwner, AuctionRoleCoAdmin, AuctionRoleTeamOwner, AuctionRoleSpectator}
+)
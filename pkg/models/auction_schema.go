@@ -7,13 +7,22 @@ import (
 )
 
 type Auction struct {
-	ID           primitive.ObjectID `bson:"_id" json:"id"`
-	AuctionName  string             `bson:"auction_name" json:"auction_name"`
-	AuctionImage string             `bson:"auction_image" json:"auction_image"`
-	CreatedBy    string             `bson:"created_by" json:"created_by"`
-	AuctionDate  time.Time          `bson:"auction_date" json:"auction_date"`
-	IsIPLAuction bool               `bson:"is_ipl_auction" json:"is_ipl_auction"`
-	JoinedBy     []string           `bson:"joined_by" json:"joined_by"`
-	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ID               primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionName      string             `bson:"auction_name" json:"auction_name"`
+	AuctionImage     string             `bson:"auction_image" json:"auction_image"`
+	CreatedBy        string             `bson:"created_by" json:"created_by"`
+	AuctionDate      time.Time          `bson:"auction_date" json:"auction_date"`
+	IsIPLAuction     bool               `bson:"is_ipl_auction" json:"is_ipl_auction"`
+	Status           string             `bson:"status,omitempty" json:"status"`
+	JoinedBy         []string           `bson:"joined_by" json:"joined_by"`
+	CoAdmins         []string           `bson:"co_admins,omitempty" json:"co_admins,omitempty"`
+	IsPrivate        bool               `bson:"is_private" json:"is_private"`
+	IsPublic         bool               `bson:"is_public" json:"is_public"`
+	RequiresApproval bool               `bson:"requires_approval" json:"requires_approval"`
+	MaxParticipants  int                `bson:"max_participants" json:"max_participants"`
+	JoinRequests     []string           `bson:"join_requests,omitempty" json:"join_requests,omitempty"`
+	BannedUsers      []string           `bson:"banned_users,omitempty" json:"banned_users,omitempty"`
+	DeletedAt        *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
 }
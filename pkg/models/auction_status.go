@@ -0,0 +1,76 @@
+package models
+
+// Auction lifecycle statuses
+const (
+	AuctionStatusDraft     = "draft"
+	AuctionStatusScheduled = "scheduled"
+	AuctionStatusRetention = "retention"
+	AuctionStatusLive      = "live"
+	AuctionStatusCompleted = "completed"
+	AuctionStatusArchived  = "archived"
+)
+
+// Operations guarded by the auction lifecycle
+const (
+	AuctionOpManagePlayers = "manage_players"
+	AuctionOpManageTeams   = "manage_teams"
+	AuctionOpUpdatePlayer  = "update_player"
+	AuctionOpRetainPlayers = "retain_players"
+	AuctionOpUpdate        = "update"
+	AuctionOpScoreMatches  = "score_matches"
+	AuctionOpAvailability  = "availability"
+	AuctionOpWaivers       = "waivers"
+)
+
+// auctionTransitions lists the statuses reachable from each status
+var auctionTransitions = map[string][]string{
+	AuctionStatusDraft:     {AuctionStatusScheduled},
+	AuctionStatusScheduled: {AuctionStatusDraft, AuctionStatusRetention, AuctionStatusLive},
+	AuctionStatusRetention: {AuctionStatusLive},
+	AuctionStatusLive:      {AuctionStatusCompleted},
+	AuctionStatusCompleted: {AuctionStatusArchived},
+}
+
+// auctionStatusOps lists the operations allowed in each status
+var auctionStatusOps = map[string][]string{
+	AuctionStatusDraft:     {AuctionOpManagePlayers, AuctionOpManageTeams, AuctionOpUpdate, AuctionOpAvailability},
+	AuctionStatusScheduled: {AuctionOpManagePlayers, AuctionOpManageTeams, AuctionOpUpdate, AuctionOpAvailability},
+	AuctionStatusRetention: {AuctionOpManagePlayers, AuctionOpManageTeams, AuctionOpRetainPlayers, AuctionOpUpdate, AuctionOpAvailability},
+	AuctionStatusLive:      {AuctionOpUpdatePlayer, AuctionOpUpdate, AuctionOpScoreMatches, AuctionOpAvailability},
+	AuctionStatusCompleted: {AuctionOpUpdate, AuctionOpScoreMatches, AuctionOpAvailability, AuctionOpWaivers},
+	AuctionStatusArchived:  {},
+}
+
+// CurrentStatus returns the lifecycle status, auctions created before statuses existed are drafts
+func (a Auction) CurrentStatus() string {
+	if a.Status == "" {
+		return AuctionStatusDraft
+	}
+	return a.Status
+}
+
+// Allows reports whether the operation is permitted in the current status
+func (a Auction) Allows(op string) bool {
+	for _, allowed := range auctionStatusOps[a.CurrentStatus()] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition reports whether the auction can move from its current status to the next one
+func (a Auction) CanTransition(next string) bool {
+	for _, status := range auctionTransitions[a.CurrentStatus()] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidAuctionStatus reports whether status is one of the lifecycle statuses
+func ValidAuctionStatus(status string) bool {
+	_, ok := auctionStatusOps[status]
+	return ok
+}
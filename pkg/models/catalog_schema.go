@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CatalogPlayer is the canonical identity of a player shared across auctions
+type CatalogPlayer struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	PlayerName string             `bson:"player_name" json:"player_name" binding:"required"`
+	Country    string             `bson:"country" json:"country"`
+	Role       string             `bson:"role" json:"role" binding:"required"`
+	IPLTeam    string             `bson:"ipl_team" json:"ipl_team"`
+	PhotoURL   string             `bson:"photo_url" json:"photo_url"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultFixtureTimezone is the display timezone of fixtures imported without one
+const DefaultFixtureTimezone = "Asia/Kolkata"
+
+// Fixture is one IPL match of the schedule followed by an auction. StartTime is
+// stored in UTC, Timezone is where the match is shown and decides its matchday.
+type Fixture struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionID   primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	MatchNumber int                `bson:"match_number" json:"match_number"`
+	HomeTeam    string             `bson:"home_team" json:"home_team"`
+	AwayTeam    string             `bson:"away_team" json:"away_team"`
+	Venue       string             `bson:"venue" json:"venue"`
+	StartTime   time.Time          `bson:"start_time" json:"start_time"`
+	Timezone    string             `bson:"timezone" json:"timezone"`
+	Matchday    int                `bson:"matchday" json:"matchday"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Involves reports whether the IPL team plays the fixture, team names are matched
+// without case
+func (f Fixture) Involves(iplTeam string) bool {
+	return iplTeam != "" && (strings.EqualFold(f.HomeTeam, iplTeam) || strings.EqualFold(f.AwayTeam, iplTeam))
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Stages of a head-to-head matchup
+const (
+	H2HStageLeague  = "league"
+	H2HStagePlayoff = "playoff"
+)
+
+// H2HLeague is the head-to-head schedule of an IPL auction, the results of its
+// matchups come from the matchday snapshots
+type H2HLeague struct {
+	AuctionID     primitive.ObjectID   `bson:"_id" json:"auction_id"`
+	Teams         []primitive.ObjectID `bson:"teams" json:"teams"`
+	StartMatchday int                  `bson:"start_matchday" json:"start_matchday"`
+	Legs          int                  `bson:"legs" json:"legs"`
+	PlayoffTeams  int                  `bson:"playoff_teams" json:"playoff_teams"` // 0 when the season ends with the league
+	Matchups      []H2HMatchup         `bson:"matchups" json:"matchups"`
+	CreatedBy     string               `bson:"created_by" json:"created_by"`
+	CreatedAt     time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// H2HMatchup pits two teams against each other in a matchday, a zero away team is a bye
+type H2HMatchup struct {
+	Matchday int                `bson:"matchday" json:"matchday"`
+	Round    int                `bson:"round" json:"round"`
+	Stage    string             `bson:"stage" json:"stage"`
+	HomeTeam primitive.ObjectID `bson:"home_team" json:"home_team"`
+	AwayTeam primitive.ObjectID `bson:"away_team,omitempty" json:"away_team"`
+}
+
+// LastLeagueMatchday returns the matchday of the final league round
+func (l H2HLeague) LastLeagueMatchday() int {
+	last := l.StartMatchday
+	for _, matchup := range l.Matchups {
+		if matchup.Stage == H2HStageLeague && matchup.Matchday > last {
+			last = matchup.Matchday
+		}
+	}
+	return last
+}
+
+// ValidPlayoffTeams reports whether a playoff bracket can be drawn for the number of teams
+func ValidPlayoffTeams(n int) bool {
+	return n == 0 || (n >= 2 && n&(n-1) == 0)
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuctionInvite is an expiring code allowing users to join a private auction
+type AuctionInvite struct {
+	Code      string             `bson:"_id" json:"code"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	CreatedBy string             `bson:"created_by" json:"created_by"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
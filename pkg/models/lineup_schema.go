@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FantasySeason tracks the matchday in play of an IPL auction, the deadline of the
+// playing XI of the next one and the free agent waivers
+type FantasySeason struct {
+	AuctionID      primitive.ObjectID `bson:"_id" json:"auction_id"`
+	Matchday       int                `bson:"matchday" json:"matchday"`
+	LockAt         *time.Time         `bson:"lock_at,omitempty" json:"lock_at,omitempty"`
+	LastRolloverAt *time.Time         `bson:"last_rollover_at,omitempty" json:"last_rollover_at,omitempty"`
+	WaiverBudget   float64            `bson:"waiver_budget,omitempty" json:"waiver_budget,omitempty"`
+	WaiverOpensAt  *time.Time         `bson:"waiver_opens_at,omitempty" json:"waiver_opens_at,omitempty"`
+	WaiverClosesAt *time.Time         `bson:"waiver_closes_at,omitempty" json:"waiver_closes_at,omitempty"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Lineup is the playing XI picked by a team for a matchday
+type Lineup struct {
+	ID          primitive.ObjectID   `bson:"_id" json:"id"`
+	AuctionID   primitive.ObjectID   `bson:"auction_id" json:"auction_id"`
+	TeamID      primitive.ObjectID   `bson:"team_id" json:"team_id"`
+	Matchday    int                  `bson:"matchday" json:"matchday"`
+	Players     []primitive.ObjectID `bson:"players" json:"players"`
+	Captain     primitive.ObjectID   `bson:"captain" json:"captain"`
+	ViceCaptain primitive.ObjectID   `bson:"vice_captain" json:"vice_captain"`
+	SelectedBy  string               `bson:"selected_by" json:"selected_by"`
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// WaiverOpen reports whether claims can be submitted in the waiver window
+func (s FantasySeason) WaiverOpen(now time.Time) bool {
+	return s.WaiverOpensAt != nil && s.WaiverClosesAt != nil &&
+		!now.Before(*s.WaiverOpensAt) && now.Before(*s.WaiverClosesAt)
+}
+
+// Locked reports whether the XI deadline of the next matchday has passed
+func (s FantasySeason) Locked(now time.Time) bool {
+	return s.LockAt != nil && !now.Before(*s.LockAt)
+}
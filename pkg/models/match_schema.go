@@ -4,14 +4,92 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type Match struct {
 	Id                primitive.ObjectID `bson:"_id" json:"_id"`
+	AuctionID         primitive.ObjectID `bson:"auction_id,omitempty" json:"auction_id,omitempty"` // unset on documents created before it was recorded
 	Matches           []int              `bson:"matches" json:"matches"`
+	Scores            []MatchScore       `bson:"scores,omitempty" json:"scores,omitempty"`
+	RulesVersion      int                `bson:"rules_version,omitempty" json:"rules_version,omitempty"`
 	PrevX1            bool               `bson:"prevX1" json:"prevX1"`
 	CurrentX1         bool               `bson:"currentX1" json:"currentX1"`
 	NextX1            bool               `bson:"nextX1" json:"nextX1"`
+	CurrentCaptaincy  string             `bson:"currentCaptaincy,omitempty" json:"currentCaptaincy,omitempty"`
+	NextCaptaincy     string             `bson:"nextCaptaincy,omitempty" json:"nextCaptaincy,omitempty"`
 	EarnedPoints      int                `bson:"earnedPoints" json:"earnedPoints"`
 	BenchedPoints     int                `bson:"benchedPoints" json:"benchedPoints"`
 	TotalPoints       int                `bson:"totalPoints" json:"totalPoints"`
+	RawTotalPoints    int                `bson:"rawTotalPoints" json:"rawTotalPoints"`
 	PrevTotalPoints   int                `bson:"prevTotalPoints" json:"prevTotalPoints"`
 	PrevEarnedPoints  int                `bson:"prevEarnedPoints" json:"prevEarnedPoints"`
 	PrevBenchedPoints int                `bson:"prevBenchedPoints" json:"prevBenchedPoints"`
 }
+
+// Captaincy of a player in the playing XI
+const (
+	CaptaincyCaptain     = "captain"
+	CaptaincyViceCaptain = "vice_captain"
+)
+
+// MatchScore is the fantasy points of a player in one IPL match. RawPoints is what the
+// scorecard was worth and Points the value after the captaincy multiplier.
+type MatchScore struct {
+	MatchNumber int     `bson:"match_number" json:"match_number"`
+	RawPoints   int     `bson:"raw_points" json:"raw_points"`
+	Multiplier  float64 `bson:"multiplier" json:"multiplier"`
+	Points      int     `bson:"points" json:"points"`
+	Earned      bool    `bson:"earned" json:"earned"`
+	Captaincy   string  `bson:"captaincy,omitempty" json:"captaincy,omitempty"`
+}
+
+// SetScore records the points of a match, replacing any earlier score of the same
+// match, and recomputes the points list and totals
+func (m *Match) SetScore(score MatchScore) {
+	replaced := false
+	for i := range m.Scores {
+		if m.Scores[i].MatchNumber == score.MatchNumber {
+			m.Scores[i] = score
+			replaced = true
+		}
+	}
+	if !replaced {
+		m.Scores = append(m.Scores, score)
+	}
+	m.Recalculate()
+}
+
+// Score returns the recorded score of a match
+func (m *Match) Score(matchNumber int) (MatchScore, bool) {
+	for _, score := range m.Scores {
+		if score.MatchNumber == matchNumber {
+			return score, true
+		}
+	}
+	return MatchScore{}, false
+}
+
+// RemoveScore drops the score of a match and recomputes the points list and totals
+func (m *Match) RemoveScore(matchNumber int) {
+	scores := m.Scores[:0]
+	for _, score := range m.Scores {
+		if score.MatchNumber != matchNumber {
+			scores = append(scores, score)
+		}
+	}
+	m.Scores = scores
+	m.Recalculate()
+}
+
+// Recalculate derives the points list and the totals from the scores
+func (m *Match) Recalculate() {
+	m.Matches = make([]int, 0, len(m.Scores))
+	m.TotalPoints, m.RawTotalPoints, m.EarnedPoints, m.BenchedPoints = 0, 0, 0, 0
+
+	for _, score := range m.Scores {
+		m.Matches = append(m.Matches, score.Points)
+		m.TotalPoints += score.Points
+		m.RawTotalPoints += score.RawPoints
+		if score.Earned {
+			m.EarnedPoints += score.Points
+		} else {
+			m.BenchedPoints += score.Points
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MatchdaySnapshot freezes the fantasy points of an auction when a matchday closes
+type MatchdaySnapshot struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	Matchday  int                `bson:"matchday" json:"matchday"`
+	Teams     []TeamSnapshot     `bson:"teams" json:"teams"`
+	Players   []PlayerSnapshot   `bson:"players" json:"players"`
+	ClosedAt  time.Time          `bson:"closed_at" json:"closed_at"`
+}
+
+// TeamSnapshot is the points of a team summed over its squad at the close of a matchday
+type TeamSnapshot struct {
+	TeamID        primitive.ObjectID `bson:"team_id" json:"team_id"`
+	TeamName      string             `bson:"team_name" json:"team_name"`
+	TotalPoints   int                `bson:"total_points" json:"total_points"`
+	EarnedPoints  int                `bson:"earned_points" json:"earned_points"`
+	BenchedPoints int                `bson:"benched_points" json:"benched_points"`
+}
+
+// PlayerSnapshot is the points of a player at the close of a matchday
+type PlayerSnapshot struct {
+	PlayerID      primitive.ObjectID `bson:"player_id" json:"player_id"`
+	TeamID        primitive.ObjectID `bson:"team_id,omitempty" json:"team_id,omitempty"`
+	TotalPoints   int                `bson:"total_points" json:"total_points"`
+	EarnedPoints  int                `bson:"earned_points" json:"earned_points"`
+	BenchedPoints int                `bson:"benched_points" json:"benched_points"`
+	InXI          bool               `bson:"in_xi" json:"in_xi"`
+}
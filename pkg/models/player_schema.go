@@ -9,6 +9,7 @@ import (
 type Player struct {
 	Id                primitive.ObjectID `bson:"_id" json:"_id"`
 	AuctionId         primitive.ObjectID `bson:"auction_id" json:"auction_id" binding:"required"`
+	CatalogId         primitive.ObjectID `bson:"catalog_id,omitempty" json:"catalog_id,omitempty"`
 	PlayerNumber      int                `bson:"player_number" json:"player_number" binding:"required"`
 	PlayerName        string             `bson:"player_name" json:"player_name" binding:"required"`
 	Country           string             `bson:"country,omitempty" json:"country,omitempty"`
@@ -22,11 +23,81 @@ type Player struct {
 	PrevFantasyPoints int                `bson:"prev_fantasy_points,omitempty" json:"prev_fantasy_points,omitempty"`
 	Bids              []Bids             `bson:"bids" json:"bids"`
 	Match             primitive.ObjectID `bson:"match,omitempty" json:"match,omitempty"`
+	Availability      *Availability      `bson:"availability,omitempty" json:"availability,omitempty"` // nil while available
+	ReplacementFor    primitive.ObjectID `bson:"replacement_for,omitempty" json:"replacement_for,omitempty"`
 	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// AvailabilityStatus returns the availability of the player, available when none was set
+func (p Player) AvailabilityStatus() string {
+	if p.Availability == nil {
+		return AvailabilityAvailable
+	}
+	return p.Availability.Status
+}
+
+// Hammer states of a player in the auction
+const (
+	HammerUpcoming = "upcoming"
+	HammerSold     = "sold"
+	HammerUnsold   = "unsold"
+	HammerRetained = "retained"
+	HammerReleased = "released" // dropped from a squad through a waiver claim
+)
+
+// FreeAgent reports whether the player can be claimed on waivers, it went unsold
+// in the auction or was released by a team
+func (p Player) FreeAgent() bool {
+	return p.CurrentTeam == "" && (p.Hammer == HammerUnsold || p.Hammer == HammerReleased)
+}
+
 type Bids struct {
 	TeamName string  `bson:"team_name" json:"team_name"`
 	Bid      float64 `bson:"bid" json:"bid"`
 }
+
+// Player roles accepted by the squad and import APIs
+const (
+	RoleBatter       = "Batter"
+	RoleBowler       = "Bowler"
+	RoleAllRounder   = "All-Rounder"
+	RoleWicketKeeper = "Wicket-Keeper"
+)
+
+// ValidPlayerRole reports whether role is one of the known player roles
+func ValidPlayerRole(role string) bool {
+	switch role {
+	case RoleBatter, RoleBowler, RoleAllRounder, RoleWicketKeeper:
+		return true
+	}
+	return false
+}
+
+// Availability statuses of a player during the season
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityInjured   = "injured"
+	AvailabilityDoubtful  = "doubtful"
+	AvailabilityWithdrawn = "withdrawn"
+	AvailabilityReplaced  = "replaced"
+)
+
+// Availability is the fitness of a player as reported during the season
+type Availability struct {
+	Status         string             `bson:"status" json:"status"`
+	Note           string             `bson:"note,omitempty" json:"note,omitempty"`
+	ExpectedReturn *time.Time         `bson:"expected_return,omitempty" json:"expected_return,omitempty"`
+	ReplacedBy     primitive.ObjectID `bson:"replaced_by,omitempty" json:"replaced_by,omitempty"`
+	UpdatedBy      string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ValidAvailability reports whether status is one of the availability statuses
+func ValidAvailability(status string) bool {
+	switch status {
+	case AvailabilityAvailable, AvailabilityInjured, AvailabilityDoubtful, AvailabilityWithdrawn, AvailabilityReplaced:
+		return true
+	}
+	return false
+}
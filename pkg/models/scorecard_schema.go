@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Performance is what a player did in one match
+type Performance struct {
+	PlayerID     primitive.ObjectID `bson:"player_id" json:"player_id" binding:"required"`
+	Runs         int                `bson:"runs" json:"runs"`
+	Balls        int                `bson:"balls" json:"balls"`
+	Fours        int                `bson:"fours" json:"fours"`
+	Sixes        int                `bson:"sixes" json:"sixes"`
+	Wickets      int                `bson:"wickets" json:"wickets"`
+	Maidens      int                `bson:"maidens" json:"maidens"`
+	BallsBowled  int                `bson:"balls_bowled,omitempty" json:"balls_bowled,omitempty"`
+	RunsConceded int                `bson:"runs_conceded,omitempty" json:"runs_conceded,omitempty"`
+	Catches      int                `bson:"catches" json:"catches"`
+	Stumpings    int                `bson:"stumpings" json:"stumpings"`
+	RunOuts      int                `bson:"run_outs" json:"run_outs"`
+}
+
+// Scorecard holds the performances of the players of an auction in one IPL match
+type Scorecard struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionID    primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	MatchNumber  int                `bson:"match_number" json:"match_number"`
+	Performances []Performance      `bson:"performances" json:"performances"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Valid reports whether every stat of the performance is non negative
+func (p Performance) Valid() bool {
+	for _, stat := range []int{p.Runs, p.Balls, p.Fours, p.Sixes, p.Wickets, p.Maidens, p.BallsBowled, p.RunsConceded, p.Catches, p.Stumpings, p.RunOuts} {
+		if stat < 0 {
+			return false
+		}
+	}
+	return p.Wickets <= 10
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Threshold awards bonus points once a count reaches At, only the highest
+// threshold reached counts
+type Threshold struct {
+	At     int `bson:"at" json:"at"`
+	Points int `bson:"points" json:"points"`
+}
+
+// Band awards points when a rate is within [From, To), a zero To has no upper bound
+type Band struct {
+	From   float64 `bson:"from" json:"from"`
+	To     float64 `bson:"to" json:"to"`
+	Points int     `bson:"points" json:"points"`
+}
+
+// ScoringRules are the fantasy points formulas of an auction
+type ScoringRules struct {
+	PointsPerRun          int         `bson:"points_per_run" json:"points_per_run"`
+	PointsPerFour         int         `bson:"points_per_four" json:"points_per_four"`
+	PointsPerSix          int         `bson:"points_per_six" json:"points_per_six"`
+	Milestones            []Threshold `bson:"milestones" json:"milestones"`
+	StrikeRateBands       []Band      `bson:"strike_rate_bands" json:"strike_rate_bands"`
+	StrikeRateMinBalls    int         `bson:"strike_rate_min_balls" json:"strike_rate_min_balls"`
+	PointsPerWicket       int         `bson:"points_per_wicket" json:"points_per_wicket"`
+	Hauls                 []Threshold `bson:"hauls" json:"hauls"`
+	PointsPerMaiden       int         `bson:"points_per_maiden" json:"points_per_maiden"`
+	EconomyBands          []Band      `bson:"economy_bands" json:"economy_bands"`
+	EconomyMinBalls       int         `bson:"economy_min_balls" json:"economy_min_balls"`
+	PointsPerCatch        int         `bson:"points_per_catch" json:"points_per_catch"`
+	CatchBonuses          []Threshold `bson:"catch_bonuses" json:"catch_bonuses"`
+	PointsPerStumping     int         `bson:"points_per_stumping" json:"points_per_stumping"`
+	PointsPerRunOut       int         `bson:"points_per_run_out" json:"points_per_run_out"`
+	CaptainMultiplier     float64     `bson:"captain_multiplier,omitempty" json:"captain_multiplier,omitempty"`
+	ViceCaptainMultiplier float64     `bson:"vice_captain_multiplier,omitempty" json:"vice_captain_multiplier,omitempty"`
+}
+
+// Standard captaincy multipliers, used by rulesets that leave them unset
+const (
+	DefaultCaptainMultiplier     = 2.0
+	DefaultViceCaptainMultiplier = 1.5
+)
+
+// ScoringRuleset is one version of the scoring rules of an auction
+type ScoringRuleset struct {
+	Version   int          `bson:"version" json:"version"`
+	Rules     ScoringRules `bson:"rules" json:"rules"`
+	CreatedBy string       `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt time.Time    `bson:"created_at" json:"created_at"`
+}
+
+// AuctionScoring holds every version of the scoring rules of an auction, the
+// latest version being the one in force
+type AuctionScoring struct {
+	AuctionID primitive.ObjectID `bson:"_id" json:"auction_id"`
+	Version   int                `bson:"version" json:"version"`
+	Versions  []ScoringRuleset   `bson:"versions" json:"versions"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Current returns the ruleset in force, the default rules when none was set
+func (s AuctionScoring) Current() ScoringRuleset {
+	if len(s.Versions) == 0 {
+		return ScoringRuleset{Rules: DefaultScoringRules()}
+	}
+	return s.Versions[len(s.Versions)-1]
+}
+
+// DefaultScoringRules are the T20 rules used by auctions without a ruleset, as version 0
+func DefaultScoringRules() ScoringRules {
+	return ScoringRules{
+		PointsPerRun:       1,
+		PointsPerFour:      1,
+		PointsPerSix:       2,
+		Milestones:         []Threshold{{At: 50, Points: 8}, {At: 100, Points: 16}},
+		StrikeRateMinBalls: 10,
+		StrikeRateBands: []Band{
+			{From: 170, Points: 6},
+			{From: 150, To: 170, Points: 4},
+			{From: 130, To: 150, Points: 2},
+			{From: 60, To: 70, Points: -2},
+			{From: 50, To: 60, Points: -4},
+			{From: 0, To: 50, Points: -6},
+		},
+		PointsPerWicket: 25,
+		Hauls:           []Threshold{{At: 3, Points: 4}, {At: 4, Points: 8}, {At: 5, Points: 16}},
+		PointsPerMaiden: 12,
+		EconomyMinBalls: 12,
+		EconomyBands: []Band{
+			{From: 0, To: 5, Points: 6},
+			{From: 5, To: 6, Points: 4},
+			{From: 6, To: 7, Points: 2},
+			{From: 10, To: 11, Points: -2},
+			{From: 11, To: 12, Points: -4},
+			{From: 12, Points: -6},
+		},
+		PointsPerCatch:        8,
+		CatchBonuses:          []Threshold{{At: 3, Points: 4}},
+		PointsPerStumping:     12,
+		PointsPerRunOut:       6,
+		CaptainMultiplier:     DefaultCaptainMultiplier,
+		ViceCaptainMultiplier: DefaultViceCaptainMultiplier,
+	}
+}
+
+// Multiplier returns the points multiplier of the captaincy
+func (r ScoringRules) Multiplier(captaincy string) float64 {
+	switch captaincy {
+	case CaptaincyCaptain:
+		if r.CaptainMultiplier > 0 {
+			return r.CaptainMultiplier
+		}
+		return DefaultCaptainMultiplier
+	case CaptaincyViceCaptain:
+		if r.ViceCaptainMultiplier > 0 {
+			return r.ViceCaptainMultiplier
+		}
+		return DefaultViceCaptainMultiplier
+	}
+	return 1
+}
+
+// Validate checks the thresholds and bands of the rules are well formed
+func (r ScoringRules) Validate() error {
+	for _, thresholds := range [][]Threshold{r.Milestones, r.Hauls, r.CatchBonuses} {
+		for _, threshold := range thresholds {
+			if threshold.At <= 0 {
+				return errors.New("thresholds must be positive")
+			}
+		}
+	}
+	for _, bands := range [][]Band{r.StrikeRateBands, r.EconomyBands} {
+		for _, band := range bands {
+			if band.From < 0 || (band.To != 0 && band.To <= band.From) {
+				return errors.New("bands must have 0 <= from < to")
+			}
+		}
+	}
+	if r.StrikeRateMinBalls < 0 || r.EconomyMinBalls < 0 {
+		return errors.New("minimum balls must not be negative")
+	}
+	for _, multiplier := range []float64{r.CaptainMultiplier, r.ViceCaptainMultiplier} {
+		if multiplier != 0 && multiplier < 1 {
+			return errors.New("captaincy multipliers must be at least 1")
+		}
+	}
+	return nil
+}
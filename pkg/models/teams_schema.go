@@ -7,12 +7,13 @@ import (
 )
 
 type Team struct {
-	ID         primitive.ObjectID   `bson:"_id" json:"id"`
-	TeamName   string               `bson:"team_name" json:"team_name"`
-	TeamImage  string               `bson:"team_image" json:"team_image"`
-	AuctionId  primitive.ObjectID   `bson:"auction_id" json:"auction_id"`
-	TeamOwners []string             `bson:"team_owners" json:"team_owners"`
-	Squad      []primitive.ObjectID `bson:"squad" json:"squad"`
-	CreatedAt  time.Time            `bson:"created_at" json:"created_at"`
-	UpdatedAt  time.Time            `bson:"updated_at" json:"updated_at"`
+	ID          primitive.ObjectID   `bson:"_id" json:"id"`
+	TeamName    string               `bson:"team_name" json:"team_name"`
+	TeamImage   string               `bson:"team_image" json:"team_image"`
+	AuctionId   primitive.ObjectID   `bson:"auction_id" json:"auction_id"`
+	TeamOwners  []string             `bson:"team_owners" json:"team_owners"`
+	Squad       []primitive.ObjectID `bson:"squad" json:"squad"`
+	WaiverSpent float64              `bson:"waiver_spent,omitempty" json:"waiver_spent,omitempty"`
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Statuses of a waiver claim
+const (
+	WaiverClaimPending   = "pending"
+	WaiverClaimWon       = "won"
+	WaiverClaimLost      = "lost"
+	WaiverClaimInvalid   = "invalid"
+	WaiverClaimCancelled = "cancelled"
+)
+
+// WaiverClaim is a blind free agent bid of a team, optionally dropping one of its players
+type WaiverClaim struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionID    primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	TeamID       primitive.ObjectID `bson:"team_id" json:"team_id"`
+	PlayerID     primitive.ObjectID `bson:"player_id" json:"player_id"`
+	DropPlayerID primitive.ObjectID `bson:"drop_player_id,omitempty" json:"drop_player_id,omitempty"`
+	Bid          float64            `bson:"bid" json:"bid"`
+	Status       string             `bson:"status" json:"status"`
+	Reason       string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	SubmittedBy  string             `bson:"submitted_by" json:"submitted_by"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	ProcessedAt  *time.Time         `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
+}
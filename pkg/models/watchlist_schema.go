@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Priority tags of a watchlisted player
+const (
+	WatchPriorityHigh   = "high"
+	WatchPriorityMedium = "medium"
+	WatchPriorityLow    = "low"
+)
+
+// WatchlistEntry is a player shortlisted by a team, visible to that team's owners only
+type WatchlistEntry struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	TeamID    primitive.ObjectID `bson:"team_id" json:"team_id"`
+	PlayerID  primitive.ObjectID `bson:"player_id" json:"player_id"`
+	Priority  string             `bson:"priority" json:"priority"`
+	MaxPrice  float64            `bson:"max_price,omitempty" json:"max_price,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// PlayerNote is a private scouting note of a team about a player
+type PlayerNote struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	AuctionID primitive.ObjectID `bson:"auction_id" json:"auction_id"`
+	TeamID    primitive.ObjectID `bson:"team_id" json:"team_id"`
+	PlayerID  primitive.ObjectID `bson:"player_id" json:"player_id"`
+	Note      string             `bson:"note" json:"note"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ValidWatchPriority reports whether priority is one of the known priority tags
+func ValidWatchPriority(priority string) bool {
+	switch priority {
+	case WatchPriorityHigh, WatchPriorityMedium, WatchPriorityLow:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrAuctionNotFound     = errors.New("auction not found")
+	ErrOperationNotAllowed = errors.New("operation not allowed in current auction status")
+)
+
+// CheckAuctionOperation verifies the status of every given auction allows the operation
+func CheckAuctionOperation(ctx context.Context, db *mongo.Database, op string, auctionIDs ...primitive.ObjectID) error {
+	var auctions []models.Auction
+
+	unique := make(map[primitive.ObjectID]bool, len(auctionIDs))
+	ids := make([]primitive.ObjectID, 0, len(auctionIDs))
+	for _, id := range auctionIDs {
+		if !unique[id] {
+			unique[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": bson.M{"$exists": false},
+	}
+	cursor, err := db.Collection("auctions").Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if err = cursor.All(ctx, &auctions); err != nil {
+		return err
+	}
+
+	if len(auctions) != len(ids) {
+		return ErrAuctionNotFound
+	}
+	for _, auction := range auctions {
+		if !auction.Allows(op) {
+			return ErrOperationNotAllowed
+		}
+	}
+	return nil
+}
+
+// AuctionGuardResponse maps an error of CheckAuctionOperation to its http status and message
+func AuctionGuardResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrAuctionNotFound):
+		return http.StatusNotFound, "Auction not found"
+	case errors.Is(err, ErrOperationNotAllowed):
+		return http.StatusConflict, "Operation not allowed in current auction status"
+	default:
+		return http.StatusInternalServerError, "Internal server error from db"
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"auction-web/pkg/models"
+	"math"
+)
+
+// PointsBreakdown splits the fantasy points of a performance by discipline
+type PointsBreakdown struct {
+	Batting  int `json:"batting"`
+	Bowling  int `json:"bowling"`
+	Fielding int `json:"fielding"`
+	Total    int `json:"total"`
+}
+
+// ScorePerformance computes the fantasy points of a performance under the rules
+func ScorePerformance(rules models.ScoringRules, p models.Performance) PointsBreakdown {
+	var breakdown PointsBreakdown
+
+	breakdown.Batting = p.Runs*rules.PointsPerRun + p.Fours*rules.PointsPerFour + p.Sixes*rules.PointsPerSix
+	breakdown.Batting += thresholdPoints(rules.Milestones, p.Runs)
+	if p.Balls > 0 && p.Balls >= rules.StrikeRateMinBalls {
+		breakdown.Batting += bandPoints(rules.StrikeRateBands, float64(p.Runs)*100/float64(p.Balls))
+	}
+
+	breakdown.Bowling = p.Wickets*rules.PointsPerWicket + p.Maidens*rules.PointsPerMaiden
+	breakdown.Bowling += thresholdPoints(rules.Hauls, p.Wickets)
+	if p.BallsBowled > 0 && p.BallsBowled >= rules.EconomyMinBalls {
+		breakdown.Bowling += bandPoints(rules.EconomyBands, float64(p.RunsConceded)*6/float64(p.BallsBowled))
+	}
+
+	breakdown.Fielding = p.Catches*rules.PointsPerCatch + p.Stumpings*rules.PointsPerStumping + p.RunOuts*rules.PointsPerRunOut
+	breakdown.Fielding += thresholdPoints(rules.CatchBonuses, p.Catches)
+
+	breakdown.Total = breakdown.Batting + breakdown.Bowling + breakdown.Fielding
+	return breakdown
+}
+
+// FantasyPoints computes the total fantasy points of a performance under the rules
+func FantasyPoints(rules models.ScoringRules, p models.Performance) int {
+	return ScorePerformance(rules, p).Total
+}
+
+// ScoreMatch turns the raw points of a player in a match into its score, the
+// captaincy multiplier only applies to points earned in the playing XI
+func ScoreMatch(rules models.ScoringRules, matchNumber, rawPoints int, earned bool, captaincy string) models.MatchScore {
+	if !earned {
+		captaincy = ""
+	}
+	multiplier := rules.Multiplier(captaincy)
+
+	return models.MatchScore{
+		MatchNumber: matchNumber,
+		RawPoints:   rawPoints,
+		Multiplier:  multiplier,
+		Points:      int(math.Round(float64(rawPoints) * multiplier)),
+		Earned:      earned,
+		Captaincy:   captaincy,
+	}
+}
+
+// thresholdPoints returns the bonus of the highest threshold reached by count
+func thresholdPoints(thresholds []models.Threshold, count int) int {
+	best, points := 0, 0
+	for _, threshold := range thresholds {
+		if count >= threshold.At && threshold.At > best {
+			best, points = threshold.At, threshold.Points
+		}
+	}
+	return points
+}
+
+// bandPoints returns the points of the first band containing the rate
+func bandPoints(bands []models.Band, rate float64) int {
+	for _, band := range bands {
+		if rate >= band.From && (band.To == 0 || rate < band.To) {
+			return band.Points
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"auction-web/pkg/models"
+	"testing"
+)
+
+func TestScoreMatch(t *testing.T) {
+	custom := models.ScoringRules{CaptainMultiplier: 3, ViceCaptainMultiplier: 1.25}
+
+	tests := []struct {
+		name      string
+		rules     models.ScoringRules
+		rawPoints int
+		earned    bool
+		captaincy string
+		want      models.MatchScore
+	}{
+		{
+			name:      "earned without captaincy",
+			rawPoints: 42,
+			earned:    true,
+			want:      models.MatchScore{MatchNumber: 7, RawPoints: 42, Multiplier: 1, Points: 42, Earned: true},
+		},
+		{
+			name:      "default captain multiplier",
+			rawPoints: 42,
+			earned:    true,
+			captaincy: models.CaptaincyCaptain,
+			want:      models.MatchScore{MatchNumber: 7, RawPoints: 42, Multiplier: 2, Points: 84, Earned: true, Captaincy: models.CaptaincyCaptain},
+		},
+		{
+			name:      "vice-captain points are rounded",
+			rawPoints: 33,
+			earned:    true,
+			captaincy: models.CaptaincyViceCaptain,
+			want:      models.MatchScore{MatchNumber: 7, RawPoints: 33, Multiplier: 1.5, Points: 50, Earned: true, Captaincy: models.CaptaincyViceCaptain},
+		},
+		{
+			name:      "custom multipliers",
+			rules:     custom,
+			rawPoints: 30,
+			earned:    true,
+			captaincy: models.CaptaincyCaptain,
+			want:      models.MatchScore{MatchNumber: 7, RawPoints: 30, Multiplier: 3, Points: 90, Earned: true, Captaincy: models.CaptaincyCaptain},
+		},
+		{
+			name:      "benched captain is not multiplied",
+			rules:     custom,
+			rawPoints: 30,
+			captaincy: models.CaptaincyCaptain,
+			want:      models.MatchScore{MatchNumber: 7, RawPoints: 30, Multiplier: 1, Points: 30},
+		},
+		{
+			name:      "negative points are multiplied too",
+			rawPoints: -5,
+			earned:    true,
+			captaincy: models.CaptaincyViceCaptain,
+			want:      models.MatchScore{MatchNumber: 7, RawPoints: -5, Multiplier: 1.5, Points: -8, Earned: true, Captaincy: models.CaptaincyViceCaptain},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreMatch(tt.rules, 7, tt.rawPoints, tt.earned, tt.captaincy)
+			if got != tt.want {
+				t.Errorf("ScoreMatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
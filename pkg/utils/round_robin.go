@@ -0,0 +1,43 @@
+package utils
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// RoundRobin pairs every team with every other once per leg with the circle
+// method. With an odd number of teams one team sits out each round, paired with
+// the zero ID as its away team. Home and away swap from one leg to the next.
+func RoundRobin(teams []primitive.ObjectID, legs int) [][][2]primitive.ObjectID {
+	slots := append([]primitive.ObjectID{}, teams...)
+	if len(slots)%2 == 1 {
+		slots = append(slots, primitive.NilObjectID)
+	}
+	n := len(slots)
+	if n < 2 {
+		return nil
+	}
+
+	rounds := make([][][2]primitive.ObjectID, 0, legs*(n-1))
+	for leg := 0; leg < legs; leg++ {
+		order := append([]primitive.ObjectID{}, slots...)
+		for round := 0; round < n-1; round++ {
+			pairs := make([][2]primitive.ObjectID, 0, n/2)
+			for i := 0; i < n/2; i++ {
+				home, away := order[i], order[n-1-i]
+				// The fixed slot alternates home and away so no team hosts every round
+				if (i == 0 && round%2 == 1) != (leg%2 == 1) {
+					home, away = away, home
+				}
+				if home.IsZero() {
+					home, away = away, home
+				}
+				pairs = append(pairs, [2]primitive.ObjectID{home, away})
+			}
+			rounds = append(rounds, pairs)
+
+			// Rotate every slot but the first one
+			last := order[n-1]
+			copy(order[2:], order[1:n-1])
+			order[1] = last
+		}
+	}
+	return rounds
+}
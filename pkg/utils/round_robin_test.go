@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRoundRobin(t *testing.T) {
+	teams := func(n int) []primitive.ObjectID {
+		ids := make([]primitive.ObjectID, 0, n)
+		for i := 1; i <= n; i++ {
+			ids = append(ids, primitive.ObjectID{byte(i)})
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name       string
+		teams      []primitive.ObjectID
+		legs       int
+		wantRounds int
+		wantPairs  int
+	}{
+		{name: "no teams", teams: teams(0), legs: 1},
+		{name: "single team sits out", teams: teams(1), legs: 1, wantRounds: 1, wantPairs: 1},
+		{name: "two teams twice", teams: teams(2), legs: 2, wantRounds: 2, wantPairs: 1},
+		{name: "four teams", teams: teams(4), legs: 1, wantRounds: 3, wantPairs: 2},
+		{name: "five teams with a bye", teams: teams(5), legs: 1, wantRounds: 5, wantPairs: 3},
+		{name: "six teams home and away", teams: teams(6), legs: 2, wantRounds: 10, wantPairs: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds := RoundRobin(tt.teams, tt.legs)
+			if len(rounds) != tt.wantRounds {
+				t.Fatalf("RoundRobin() has %d rounds, want %d", len(rounds), tt.wantRounds)
+			}
+
+			hosted := make(map[[2]primitive.ObjectID]int)
+			byes := make(map[primitive.ObjectID]int)
+			for r, round := range rounds {
+				if len(round) != tt.wantPairs {
+					t.Fatalf("round %d has %d pairs, want %d", r+1, len(round), tt.wantPairs)
+				}
+				playing := make(map[primitive.ObjectID]bool, len(tt.teams))
+				for _, pair := range round {
+					home, away := pair[0], pair[1]
+					if home.IsZero() {
+						t.Fatalf("round %d has a bye as the home team", r+1)
+					}
+					if playing[home] || (!away.IsZero() && playing[away]) {
+						t.Fatalf("round %d has a team playing twice", r+1)
+					}
+					playing[home] = true
+					if away.IsZero() {
+						byes[home]++
+						continue
+					}
+					playing[away] = true
+					hosted[pair]++
+				}
+			}
+
+			// Every team hosts every other once per pair of legs and meets it once per leg
+			for _, home := range tt.teams {
+				for _, away := range tt.teams {
+					if home == away {
+						continue
+					}
+					met := hosted[[2]primitive.ObjectID{home, away}] + hosted[[2]primitive.ObjectID{away, home}]
+					if met != tt.legs {
+						t.Errorf("teams %s and %s meet %d times, want %d", home.Hex(), away.Hex(), met, tt.legs)
+					}
+					if tt.legs == 2 && hosted[[2]primitive.ObjectID{home, away}] != 1 {
+						t.Errorf("team %s hosts %s %d times, want 1", home.Hex(), away.Hex(), hosted[[2]primitive.ObjectID{home, away}])
+					}
+				}
+				if len(tt.teams)%2 == 1 && byes[home] != tt.legs {
+					t.Errorf("team %s sits out %d rounds, want %d", home.Hex(), byes[home], tt.legs)
+				}
+			}
+		})
+	}
+}
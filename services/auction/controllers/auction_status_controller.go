@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// TransitionAuctionController returns the handler moving an auction to the given lifecycle status
+func (a *API) TransitionAuctionController(next string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			request  auctionAPIRequest
+			auction  models.Auction
+			response models.Auction
+		)
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			a.logger.Error("failed to bind auction transition request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		email := c.GetString("email")
+		if email == "" {
+			a.logger.Error("failed to fetch email from token")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+			return
+		}
+
+		filter := bson.M{
+			"_id":        request.AuctionID,
+			"created_by": email,
+			"deleted_at": bson.M{"$exists": false},
+		}
+		err := a.MongoDBClient.Collection("auctions").FindOne(ctx, filter).Decode(&auction)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found or you are not authorized to update it"})
+				return
+			}
+			a.logger.Error("failed to find auction in database", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find auction"})
+			return
+		}
+
+		if !auction.CanTransition(next) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": fmt.Sprintf("Auction cannot move from %s to %s", auction.CurrentStatus(), next),
+			})
+			return
+		}
+
+		// Match on the status read above so concurrent transitions cannot both apply
+		filter["status"] = auction.Status
+		if auction.Status == "" {
+			filter["status"] = bson.M{"$exists": false}
+		}
+		update := bson.M{
+			"$set": bson.M{
+				"status":     next,
+				"updated_at": time.Now(),
+			},
+		}
+
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = a.MongoDBClient.Collection("auctions").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Auction status changed, please retry"})
+				return
+			}
+			a.logger.Error("failed to update auction status", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update auction status"})
+			return
+		}
+
+		// Status is part of the listing, so every member's list is stale now
+		if err = a.clearAuctionListCache(ctx, append([]string{response.CreatedBy}, response.JoinedBy...)...); err != nil {
+			a.logger.Error("failed to delete auctions from cache", zap.Error(err))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Auction status updated successfully",
+			"auction": response,
+		})
+	}
+}
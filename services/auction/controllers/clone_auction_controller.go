@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type cloneAuctionRequest struct {
+	AuctionID           primitive.ObjectID `json:"auction_id" binding:"required"`
+	AuctionName         string             `json:"auction_name"`
+	AuctionDate         time.Time          `json:"auction_date"`
+	IncludeParticipants bool               `json:"include_participants"`
+	IncludeMatches      bool               `json:"include_matches"`
+}
+
+// CloneAuctionController copies an auction with its teams and players into a new auction owned by the caller
+func (a *API) CloneAuctionController(c *gin.Context) {
+	var (
+		request cloneAuctionRequest
+		source  models.Auction
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind clone auction request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if email == "" {
+		a.logger.Error("failed to fetch email from token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+		return
+	}
+
+	// Only undeleted auctions the caller created, administers or joined can be used as a template
+	filter := bson.M{
+		"_id":        request.AuctionID,
+		"deleted_at": bson.M{"$exists": false},
+		"$or": []bson.M{
+			{"created_by": email},
+			{"co_admins": email},
+			{"joined_by": email},
+		},
+	}
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, filter).Decode(&source)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found or you are not authorized to clone it"})
+			return
+		}
+		a.logger.Error("failed to find auction to clone", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find auction"})
+		return
+	}
+
+	// Carrying over the participants invites them to the clone, which only the admins may do
+	if request.IncludeParticipants && source.CreatedBy != email && !slices.Contains(source.CoAdmins, email) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the auction admins can clone its participants"})
+		return
+	}
+
+	if request.AuctionName == "" {
+		request.AuctionName = source.AuctionName + " (copy)"
+	}
+	if request.AuctionDate.IsZero() {
+		request.AuctionDate = source.AuctionDate
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start mongo session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		return a.cloneAuction(sessCtx, source, request, email)
+	})
+	if err != nil {
+		a.logger.Error("failed to clone auction", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clone auction"})
+		return
+	}
+	clone := result.(models.Auction)
+
+	// Clear cache of the new owner and of the carried over participants
+	if err = a.clearAuctionListCache(ctx, append([]string{email}, clone.JoinedBy...)...); err != nil {
+		a.logger.Error("failed to delete auctions from cache", zap.Error(err))
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Auction cloned successfully",
+		"auction": clone,
+	})
+}
+
+// cloneAuction inserts the copy of the auction, its teams and its players
+func (a *API) cloneAuction(ctx mongo.SessionContext, source models.Auction, request cloneAuctionRequest, email string) (models.Auction, error) {
+	var (
+		now     = time.Now()
+		teams   []models.Team
+		players []models.Player
+	)
+
+	clone := models.Auction{
+		ID:               primitive.NewObjectID(),
+		AuctionName:      request.AuctionName,
+		AuctionImage:     source.AuctionImage,
+		CreatedBy:        email,
+		AuctionDate:      request.AuctionDate,
+		IsIPLAuction:     source.IsIPLAuction,
+		Status:           models.AuctionStatusDraft,
+		JoinedBy:         []string{},
+		IsPrivate:        source.IsPrivate,
+		IsPublic:         source.IsPublic,
+		RequiresApproval: source.RequiresApproval,
+		MaxParticipants:  source.MaxParticipants,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if request.IncludeParticipants {
+		// The source creator becomes a participant of the clone
+		for _, participant := range append([]string{source.CreatedBy}, source.JoinedBy...) {
+			if participant != email {
+				clone.JoinedBy = append(clone.JoinedBy, participant)
+			}
+		}
+	}
+
+	if _, err := a.MongoDBClient.Collection("auctions").InsertOne(ctx, clone); err != nil {
+		return clone, err
+	}
+
+	// Teams are copied without their squads
+	cursor, err := a.MongoDBClient.Collection("teams").Find(ctx, bson.M{"auction_id": source.ID})
+	if err != nil {
+		return clone, err
+	}
+	if err = cursor.All(ctx, &teams); err != nil {
+		return clone, err
+	}
+
+	if len(teams) > 0 {
+		teamDocs := make([]any, 0, len(teams))
+		for _, team := range teams {
+			owners := []string{}
+			if request.IncludeParticipants {
+				owners = append(owners, team.TeamOwners...)
+			}
+			teamDocs = append(teamDocs, models.Team{
+				ID:         primitive.NewObjectID(),
+				TeamName:   team.TeamName,
+				TeamImage:  team.TeamImage,
+				AuctionId:  clone.ID,
+				TeamOwners: owners,
+				Squad:      []primitive.ObjectID{},
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			})
+		}
+		if _, err = a.MongoDBClient.Collection("teams").InsertMany(ctx, teamDocs); err != nil {
+			return clone, err
+		}
+	}
+
+	// Players are reset to upcoming with their bids cleared
+	cursor, err = a.MongoDBClient.Collection("players").Find(ctx, bson.M{"auction_id": source.ID})
+	if err != nil {
+		return clone, err
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		return clone, err
+	}
+	if len(players) == 0 {
+		return clone, nil
+	}
+
+	sourceMatches := make(map[primitive.ObjectID]models.Match)
+	if request.IncludeMatches {
+		var matches []models.Match
+		matchIDs := make([]primitive.ObjectID, 0, len(players))
+		for _, player := range players {
+			if !player.Match.IsZero() {
+				matchIDs = append(matchIDs, player.Match)
+			}
+		}
+		cursor, err = a.MongoDBClient.Collection("matches").Find(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+		if err != nil {
+			return clone, err
+		}
+		if err = cursor.All(ctx, &matches); err != nil {
+			return clone, err
+		}
+		for _, match := range matches {
+			sourceMatches[match.Id] = match
+		}
+	}
+
+	playerDocs := make([]any, 0, len(players))
+	matchDocs := make([]any, 0, len(players))
+	for _, player := range players {
+		player.Id = primitive.NewObjectID()
+		player.AuctionId = clone.ID
+		player.CurrentTeam = ""
+		player.Hammer = "upcoming"
+		player.SellingPrice = float64(0)
+		player.Bids = []models.Bids{}
+		player.Availability = nil
+		player.ReplacementFor = primitive.NilObjectID
+		player.CreatedAt = now
+		player.UpdatedAt = now
+
+		if clone.IsIPLAuction {
+			// Only the scores carry over, the clone starts without squads so no XI,
+			// captaincy or matchday snapshot of the source applies to it
+			match := models.Match{Matches: []int{}}
+			if sourceMatch, ok := sourceMatches[player.Match]; ok {
+				match.Matches = sourceMatch.Matches
+				match.Scores = sourceMatch.Scores
+				match.RulesVersion = sourceMatch.RulesVersion
+				if len(match.Scores) > 0 {
+					match.Recalculate()
+				}
+			}
+			match.Id = primitive.NewObjectID()
+			match.AuctionID = clone.ID
+			matchDocs = append(matchDocs, match)
+			player.Match = match.Id
+		} else {
+			player.Match = primitive.NilObjectID
+		}
+		playerDocs = append(playerDocs, player)
+	}
+
+	if len(matchDocs) > 0 {
+		if _, err = a.MongoDBClient.Collection("matches").InsertMany(ctx, matchDocs); err != nil {
+			return clone, err
+		}
+	}
+	if _, err = a.MongoDBClient.Collection("players").InsertMany(ctx, playerDocs); err != nil {
+		return clone, err
+	}
+
+	return clone, nil
+}
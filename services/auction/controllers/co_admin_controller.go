@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type coAdminRequest struct {
+	AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	Email     string             `json:"email" binding:"required"`
+}
+
+// CoAdminController returns the handler granting or revoking the co-admin role of a participant
+func (a *API) CoAdminController(grant bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			request  coAdminRequest
+			response models.Auction
+		)
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			a.logger.Error("failed to bind co-admin request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		email := c.GetString("email")
+		if email == "" {
+			a.logger.Error("failed to fetch email from token")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+			return
+		}
+
+		// Only the owner manages co-admins, and only participants can become one
+		filter := bson.M{
+			"_id":        request.AuctionID,
+			"created_by": email,
+			"deleted_at": bson.M{"$exists": false},
+		}
+		update := bson.M{
+			"$pull": bson.M{"co_admins": request.Email},
+			"$set":  bson.M{"updated_at": time.Now()},
+		}
+		if grant {
+			filter["joined_by"] = request.Email
+			update = bson.M{
+				"$addToSet": bson.M{"co_admins": request.Email},
+				"$set":      bson.M{"updated_at": time.Now()},
+			}
+		}
+
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err := a.MongoDBClient.Collection("auctions").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Auction or participant not found, or you are not authorized to update it"})
+				return
+			}
+			a.logger.Error("failed to update co-admins", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update co-admins"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Co-admins updated successfully",
+			"auction": response,
+		})
+	}
+}
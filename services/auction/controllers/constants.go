@@ -1,9 +1,30 @@
 package controllers
 
-import "time"
+import (
+	"auction-web/pkg/models"
+	"time"
+)
 
 var (
-	TTLTime         = 1 * time.Hour
-	auctionCacheKey = "auction_list_%s_%s"
-	teamCacheKey    = "team_list_%s"
+	TTLTime               = 1 * time.Hour
+	auctionCacheKey       = "auction_list_%s_%s"
+	auctionCacheIndexKey  = "auction_list_keys_%s"
+	publicAuctionCacheKey = "auction_public_list:%s"
+	PublicTTLTime         = 1 * time.Minute
+	DefaultPageSize       = int64(20)
+
+	// discoverableStatuses are the statuses of public auctions listed by default
+	discoverableStatuses = []string{
+		models.AuctionStatusScheduled,
+		models.AuctionStatusRetention,
+		models.AuctionStatusLive,
+	}
+	MaxPageSize    = int64(100)
+	teamCacheKey   = "team_list_%s"
+	playerCacheKey = "players:auction:%s"
+	leaderboardKey = "leaderboard:auction:%s"
+	InviteTTL      = 7 * 24 * time.Hour
+	RestoreWindow  = 7 * 24 * time.Hour
+	PurgeInterval  = 1 * time.Hour
+	ScanBatchSize  = int64(100)
 )
@@ -4,6 +4,8 @@ import (
 	"auction-web/internal/config"
 	"auction-web/internal/database"
 	"auction-web/internal/logger"
+	"auction-web/pkg/middlewares"
+	"auction-web/pkg/models"
 	"context"
 
 	"github.com/gin-gonic/gin"
@@ -60,21 +62,64 @@ func (a *API) RegisterRoutes(router *gin.Engine) {
 
 	auctionGroup := router.Group("/api/v1/auction")
 
+	// Per auction authorization of the team routes
+	admins := middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromBody, models.AuctionAdminRoles...)
+	teamEditors := middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromBody, append(models.AuctionAdminRoles, models.AuctionRoleTeamOwner)...)
+	members := middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromBody, models.AuctionMemberRoles...)
+
 	auctionGroup.GET("/all", a.GetAllAuctionsController)
 
-	auctionGroup.POST("/get", a.GetAuctionController)
+	auctionGroup.GET("/discover", a.DiscoverAuctionsController)
+
+	auctionGroup.POST("/spectate", members, a.SpectatorViewController)
+
+	auctionGroup.POST("/get", members, a.GetAuctionController)
 
 	auctionGroup.POST("/create", a.CreateAuctionController)
 
 	auctionGroup.POST("/join", a.JoinAuctionController)
 
+	auctionGroup.DELETE("", a.DeleteAuctionController)
+
+	auctionGroup.POST("/restore", a.RestoreAuctionController)
+
+	auctionGroup.POST("/clone", a.CloneAuctionController)
+
+	auctionGroup.POST("/invite", a.CreateInviteController)
+
+	auctionGroup.POST("/request/approve", admins, a.JoinRequestController(true))
+
+	auctionGroup.POST("/request/reject", admins, a.JoinRequestController(false))
+
+	auctionGroup.POST("/draft", a.TransitionAuctionController(models.AuctionStatusDraft))
+
+	auctionGroup.POST("/schedule", a.TransitionAuctionController(models.AuctionStatusScheduled))
+
+	auctionGroup.POST("/retention", a.TransitionAuctionController(models.AuctionStatusRetention))
+
+	auctionGroup.POST("/start", a.TransitionAuctionController(models.AuctionStatusLive))
+
+	auctionGroup.POST("/complete", a.TransitionAuctionController(models.AuctionStatusCompleted))
+
+	auctionGroup.POST("/archive", a.TransitionAuctionController(models.AuctionStatusArchived))
+
 	auctionGroup.PATCH("/update", a.UpdateAuctionController)
 
-	auctionGroup.PATCH("/team", a.UpdateTeamController)
+	auctionGroup.POST("/leave", a.LeaveAuctionController)
+
+	auctionGroup.POST("/remove", admins, a.RemoveParticipantController)
+
+	auctionGroup.POST("/unban", admins, a.UnbanParticipantController)
+
+	auctionGroup.POST("/admin", a.CoAdminController(true))
+
+	auctionGroup.DELETE("/admin", a.CoAdminController(false))
+
+	auctionGroup.PATCH("/team", teamEditors, a.UpdateTeamController)
 
-	auctionGroup.POST("/team/all", a.GetAllTeamsController)
+	auctionGroup.POST("/team/all", members, a.GetAllTeamsController)
 
-	auctionGroup.POST("/team", a.CreateTeamController)
+	auctionGroup.POST("/team", admins, a.CreateTeamController)
 
-	auctionGroup.DELETE("/team", a.DeleteTeamController)
+	auctionGroup.DELETE("/team", admins, a.DeleteTeamController)
 }
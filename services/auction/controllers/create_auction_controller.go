@@ -4,7 +4,6 @@ import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -26,6 +25,11 @@ func (a *API) CreateAuctionController(c *gin.Context) {
 		return
 	}
 
+	if request.IsPublic && request.IsPrivate {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Auction cannot be both public and private"})
+		return
+	}
+
 	email := c.GetString("email")
 	if email == "" {
 		a.logger.Error("failed to fetch email from token")
@@ -34,14 +38,19 @@ func (a *API) CreateAuctionController(c *gin.Context) {
 	}
 
 	auctionDoc := bson.M{
-		"auction_name":   request.AuctionName,
-		"auction_image":  request.AuctionImage,
-		"auction_date":   request.AuctionDate,
-		"created_by":     email,
-		"is_ipl_auction": request.IsIPLAuction,
-		"joined_by":      []string{},
-		"created_at":     time.Now(),
-		"updated_at":     time.Now(),
+		"auction_name":      request.AuctionName,
+		"auction_image":     request.AuctionImage,
+		"auction_date":      request.AuctionDate,
+		"created_by":        email,
+		"is_ipl_auction":    request.IsIPLAuction,
+		"is_private":        request.IsPrivate,
+		"is_public":         request.IsPublic,
+		"requires_approval": request.RequiresApproval,
+		"max_participants":  request.MaxParticipants,
+		"status":            models.AuctionStatusDraft,
+		"joined_by":         []string{},
+		"created_at":        time.Now(),
+		"updated_at":        time.Now(),
 	}
 
 	res, err := a.MongoDBClient.Collection("auctions").InsertOne(ctx, auctionDoc)
@@ -52,16 +61,12 @@ func (a *API) CreateAuctionController(c *gin.Context) {
 	}
 
 	// Clear cache for all auction types for this user
-	cacheKeys := []string{
-		fmt.Sprintf(auctionCacheKey, "create", email),
-		fmt.Sprintf(auctionCacheKey, "all", email),
-		fmt.Sprintf(auctionCacheKey, "join", email),
-	}
-	if _, err = a.RedisClient.Del(ctx, cacheKeys...).Result(); err != nil {
+	if err = a.clearAuctionListCache(ctx, email); err != nil {
 		a.logger.Error("failed to delete create auctions from cache", zap.Error(err))
 	}
 
 	request.ID = res.InsertedID.(primitive.ObjectID)
+	request.Status = models.AuctionStatusDraft
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Auction created successfully",
@@ -3,6 +3,7 @@ package controllers
 import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
 	"fmt"
 	"net/http"
@@ -26,12 +27,12 @@ func (a *API) CreateTeamController(c *gin.Context) {
 		return
 	}
 
-	// email := c.GetString("email")
-	// if email == "" {
-	// 	a.logger.Error("failed to fetch email from token")
-	// 	c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
-	// 	return
-	// }
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManageTeams, request.AuctionId); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionId))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
 
 	teamDoc := bson.M{
 		"team_name":   request.TeamName,
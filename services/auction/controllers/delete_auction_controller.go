@@ -0,0 +1,344 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type deleteAuctionRequest struct {
+	AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	Soft      bool               `json:"soft"`
+}
+
+// DeleteAuctionController deletes an auction owned by the caller, soft deleted
+// auctions can be restored until the restore window ends
+func (a *API) DeleteAuctionController(c *gin.Context) {
+	var (
+		request deleteAuctionRequest
+		auction models.Auction
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind delete auction request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if email == "" {
+		a.logger.Error("failed to fetch email from token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+		return
+	}
+
+	filter := bson.M{
+		"_id":        request.AuctionID,
+		"created_by": email,
+	}
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, filter).Decode(&auction)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found or you are not authorized to delete it"})
+			return
+		}
+		a.logger.Error("failed to find auction in database", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find auction"})
+		return
+	}
+
+	if request.Soft {
+		if auction.DeletedAt != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Auction is already deleted"})
+			return
+		}
+
+		now := time.Now()
+		update := bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}}
+		if _, err = a.MongoDBClient.Collection("auctions").UpdateOne(ctx, filter, update); err != nil {
+			a.logger.Error("failed to soft delete auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete auction"})
+			return
+		}
+
+		if err = a.clearAuctionListCache(ctx, append([]string{auction.CreatedBy}, auction.JoinedBy...)...); err != nil {
+			a.logger.Error("failed to delete auctions from cache", zap.Error(err))
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message":       "Auction deleted successfully",
+			"restore_until": now.Add(RestoreWindow),
+		})
+		return
+	}
+
+	if err = a.cascadeDeleteAuction(ctx, auction); err != nil {
+		a.logger.Error("failed to delete auction", zap.Error(err), zap.Any("auction_id", auction.ID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete auction, retry to resume"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Auction deleted successfully",
+	})
+}
+
+// RestoreAuctionController restores a soft deleted auction within the restore window
+func (a *API) RestoreAuctionController(c *gin.Context) {
+	var (
+		request  auctionAPIRequest
+		response models.Auction
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind restore auction request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if email == "" {
+		a.logger.Error("failed to fetch email from token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+		return
+	}
+
+	filter := bson.M{
+		"_id":        request.AuctionID,
+		"created_by": email,
+		"deleted_at": bson.M{"$gte": time.Now().Add(-RestoreWindow)},
+	}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := a.MongoDBClient.Collection("auctions").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No restorable auction found"})
+			return
+		}
+		a.logger.Error("failed to restore auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore auction"})
+		return
+	}
+
+	if err = a.clearAuctionListCache(ctx, append([]string{response.CreatedBy}, response.JoinedBy...)...); err != nil {
+		a.logger.Error("failed to delete auctions from cache", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Auction restored successfully",
+		"auction": response,
+	})
+}
+
+// cascadeDeleteAuction deletes the auction and everything linked to it. Progress is
+// recorded after every step, so calling it again after a failure resumes the delete.
+func (a *API) cascadeDeleteAuction(ctx context.Context, auction models.Auction) error {
+	var job models.AuctionDeletion
+
+	deletions := a.MongoDBClient.Collection("auction_deletions")
+
+	// Start a new job, or pick up the one left behind by a failed attempt
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := deletions.FindOneAndUpdate(ctx, bson.M{"_id": auction.ID}, bson.M{
+		"$setOnInsert": bson.M{
+			"members":    append([]string{auction.CreatedBy}, auction.JoinedBy...),
+			"step":       models.DeletionStepMatches,
+			"created_at": now,
+		},
+		"$set": bson.M{"updated_at": now},
+	}, opts).Decode(&job)
+	if err != nil {
+		return err
+	}
+
+	// Hide the auction before the first step so a delete that stops halfway never
+	// leaves a partial auction visible or usable
+	_, err = a.MongoDBClient.Collection("auctions").UpdateOne(ctx,
+		bson.M{"_id": auction.ID, "deleted_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	return a.runDeletion(ctx, job)
+}
+
+// runDeletion executes the remaining steps of a deletion job
+func (a *API) runDeletion(ctx context.Context, job models.AuctionDeletion) error {
+	deletions := a.MongoDBClient.Collection("auction_deletions")
+
+	for job.Step < models.DeletionStepDone {
+		if err := a.runDeletionStep(ctx, job); err != nil {
+			return fmt.Errorf("deletion step %d: %w", job.Step, err)
+		}
+
+		job.Step++
+		update := bson.M{"$set": bson.M{"step": job.Step, "updated_at": time.Now()}}
+		if _, err := deletions.UpdateByID(ctx, job.AuctionID, update); err != nil {
+			return err
+		}
+	}
+
+	_, err := deletions.DeleteOne(ctx, bson.M{"_id": job.AuctionID})
+	return err
+}
+
+// runDeletionStep executes one idempotent step of a cascading delete
+func (a *API) runDeletionStep(ctx context.Context, job models.AuctionDeletion) error {
+	byAuction := bson.M{"auction_id": job.AuctionID}
+
+	switch job.Step {
+	case models.DeletionStepMatches:
+		matchIDs, err := a.MongoDBClient.Collection("players").Distinct(ctx, "match", byAuction)
+		if err != nil {
+			return err
+		}
+		if len(matchIDs) == 0 {
+			return nil
+		}
+		_, err = a.MongoDBClient.Collection("matches").DeleteMany(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+		return err
+	case models.DeletionStepPlayers:
+		_, err := a.MongoDBClient.Collection("players").DeleteMany(ctx, byAuction)
+		return err
+	case models.DeletionStepTeams:
+		_, err := a.MongoDBClient.Collection("teams").DeleteMany(ctx, byAuction)
+		return err
+	case models.DeletionStepInvites:
+		_, err := a.MongoDBClient.Collection("auction_invites").DeleteMany(ctx, byAuction)
+		return err
+	case models.DeletionStepScouting:
+		if _, err := a.MongoDBClient.Collection("watchlists").DeleteMany(ctx, byAuction); err != nil {
+			return err
+		}
+		_, err := a.MongoDBClient.Collection("player_notes").DeleteMany(ctx, byAuction)
+		return err
+	case models.DeletionStepFantasy:
+		for _, collection := range []string{"scorecards", "lineups", "matchday_snapshots", "fixtures", "waiver_claims"} {
+			if _, err := a.MongoDBClient.Collection(collection).DeleteMany(ctx, byAuction); err != nil {
+				return err
+			}
+		}
+		for _, collection := range []string{"scoring_rules", "fantasy_seasons", "h2h_leagues"} {
+			if _, err := a.MongoDBClient.Collection(collection).DeleteOne(ctx, bson.M{"_id": job.AuctionID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case models.DeletionStepCache:
+		if err := a.clearAuctionListCache(ctx, job.Members...); err != nil {
+			return err
+		}
+		cacheKeys := []string{fmt.Sprintf(teamCacheKey, job.AuctionID), fmt.Sprintf(leaderboardKey, job.AuctionID.Hex())}
+		// SCAN walks the keyspace in batches where KEYS would block redis
+		iter := a.RedisClient.Scan(ctx, 0, fmt.Sprintf(playerCacheKey, job.AuctionID.Hex())+"*", ScanBatchSize).Iterator()
+		for iter.Next(ctx) {
+			cacheKeys = append(cacheKeys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		_, err := a.RedisClient.Del(ctx, cacheKeys...).Result()
+		return err
+	case models.DeletionStepAuction:
+		_, err := a.MongoDBClient.Collection("auctions").DeleteOne(ctx, bson.M{"_id": job.AuctionID})
+		return err
+	}
+	return nil
+}
+
+// PurgeDeletedAuctions periodically finishes interrupted deletes and hard deletes
+// soft deleted auctions whose restore window has ended
+func (a *API) PurgeDeletedAuctions(ctx context.Context) {
+	ticker := time.NewTicker(PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		a.purgeDeletedAuctions(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeDeletedAuctions runs a single purge pass
+func (a *API) purgeDeletedAuctions(parent context.Context) {
+	var (
+		auctions []models.Auction
+		pending  []models.AuctionDeletion
+	)
+
+	ctx, cancel := context.WithTimeout(parent, constants.DBTimeout)
+	defer cancel()
+
+	cursor, err := a.MongoDBClient.Collection("auction_deletions").Find(ctx, bson.M{})
+	if err != nil {
+		a.logger.Error("failed to fetch pending auction deletions", zap.Error(err))
+		return
+	}
+	if err = cursor.All(ctx, &pending); err != nil {
+		a.logger.Error("failed to decode pending auction deletions", zap.Error(err))
+		return
+	}
+
+	// Interrupted deletes are resumed first
+	pendingIDs := make([]primitive.ObjectID, 0, len(pending))
+	for _, job := range pending {
+		pendingIDs = append(pendingIDs, job.AuctionID)
+
+		jobCtx, jobCancel := context.WithTimeout(parent, constants.DBTimeout)
+		if err = a.runDeletion(jobCtx, job); err != nil {
+			a.logger.Error("failed to resume auction deletion", zap.Error(err), zap.Any("auction_id", job.AuctionID))
+		}
+		jobCancel()
+	}
+
+	filter := bson.M{
+		"deleted_at": bson.M{"$lt": time.Now().Add(-RestoreWindow)},
+		"_id":        bson.M{"$nin": pendingIDs},
+	}
+	cursor, err = a.MongoDBClient.Collection("auctions").Find(ctx, filter)
+	if err != nil {
+		a.logger.Error("failed to fetch auctions to purge", zap.Error(err))
+		return
+	}
+	if err = cursor.All(ctx, &auctions); err != nil {
+		a.logger.Error("failed to decode auctions to purge", zap.Error(err))
+		return
+	}
+
+	for _, auction := range auctions {
+		jobCtx, jobCancel := context.WithTimeout(parent, constants.DBTimeout)
+		if err = a.cascadeDeleteAuction(jobCtx, auction); err != nil {
+			a.logger.Error("failed to purge auction", zap.Error(err), zap.Any("auction_id", auction.ID))
+		}
+		jobCancel()
+	}
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
 	"fmt"
 	"net/http"
@@ -32,6 +34,13 @@ func (a *API) DeleteTeamController(c *gin.Context) {
 		return
 	}
 
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManageTeams, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
 	filter := bson.M{
 		"_id":        request.ID,
 		"auction_id": request.AuctionID,
@@ -49,9 +58,24 @@ func (a *API) DeleteTeamController(c *gin.Context) {
 		return
 	}
 
+	// The watchlist and notes of the team go with it
+	scoutingFilter := bson.M{"team_id": request.ID, "auction_id": request.AuctionID}
+	if _, err = a.MongoDBClient.Collection("watchlists").DeleteMany(ctx, scoutingFilter); err != nil {
+		a.logger.Warn("failed to delete watchlist of team", zap.Error(err))
+	}
+	if _, err = a.MongoDBClient.Collection("player_notes").DeleteMany(ctx, scoutingFilter); err != nil {
+		a.logger.Warn("failed to delete notes of team", zap.Error(err))
+	}
+	if _, err = a.MongoDBClient.Collection("waiver_claims").DeleteMany(ctx, scoutingFilter); err != nil {
+		a.logger.Warn("failed to delete waiver claims of team", zap.Error(err))
+	}
+
 	// If team is deleted, we need to delete old data from cache
-	cacheKeys := fmt.Sprintf(teamCacheKey, request.AuctionID)
-	if _, err = a.RedisClient.Del(ctx, cacheKeys).Result(); err != nil {
+	cacheKeys := []string{
+		fmt.Sprintf(teamCacheKey, request.AuctionID),
+		fmt.Sprintf(leaderboardKey, request.AuctionID.Hex()),
+	}
+	if _, err = a.RedisClient.Del(ctx, cacheKeys...).Result(); err != nil {
 		a.logger.Error("failed to delete teams from cache", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from redis"})
 		return
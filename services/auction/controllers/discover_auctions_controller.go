@@ -0,0 +1,216 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type spectatorTeam struct {
+	models.Team
+	Players []models.Player `json:"players"`
+	Spent   float64         `json:"spent"`
+}
+
+// DiscoverAuctionsController lists the upcoming and live public auctions
+func (a *API) DiscoverAuctionsController(c *gin.Context) {
+	var auctions []models.Auction
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	query, err := parseAuctionListQuery(c, "public")
+	if err != nil {
+		a.logger.Warn("invalid public auction query", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Status != "" && !slices.Contains(discoverableStatuses, query.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+	auctionsKey := fmt.Sprintf(publicAuctionCacheKey, query.normalize())
+
+	// Public listings are shared by every user and only cached briefly
+	val, err := a.RedisClient.Get(ctx, auctionsKey).Result()
+	if err == nil {
+		var cachedPage auctionListPage
+		if err = json.Unmarshal([]byte(val), &cachedPage); err == nil {
+			c.JSON(http.StatusOK, gin.H{
+				"message":     "Public auctions fetched successfully from cache",
+				"auctions":    cachedPage.Auctions,
+				"next_cursor": cachedPage.NextCursor,
+			})
+			return
+		}
+		a.logger.Warn("failed to unmarshal public auctions from cache", zap.Error(err))
+	}
+
+	filter, err := query.filter("")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+		return
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: query.Sort, Value: query.Order}, {Key: "_id", Value: query.Order}}).
+		SetLimit(query.Limit + 1)
+
+	cursor, err := a.MongoDBClient.Collection("auctions").Find(ctx, filter, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch public auctions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &auctions); err != nil {
+		a.logger.Error("failed to decode public auctions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	page := auctionListPage{Auctions: []auctionAPIResp{}}
+	if int64(len(auctions)) > query.Limit {
+		auctions = auctions[:query.Limit]
+		page.NextCursor = query.encodeCursor(auctions[len(auctions)-1])
+	}
+	for _, auction := range auctions {
+		page.Auctions = append(page.Auctions, auctionAPIResp{
+			AuctionID:    auction.ID,
+			AuctionName:  auction.AuctionName,
+			AuctionImage: auction.AuctionImage,
+			AuctionDate:  auction.AuctionDate,
+			IsIPLAuction: auction.IsIPLAuction,
+			Status:       auction.CurrentStatus(),
+		})
+	}
+
+	if jsonData, err := json.Marshal(page); err == nil {
+		if err = a.RedisClient.Set(ctx, auctionsKey, jsonData, PublicTTLTime).Err(); err != nil {
+			a.logger.Warn("failed to set public auctions in redis", zap.Error(err))
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Public auctions fetched successfully",
+		"auctions":    page.Auctions,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// SpectatorViewController returns the read-only view of an auction: its teams,
+// their squads and the sales so far
+func (a *API) SpectatorViewController(c *gin.Context) {
+	var (
+		request auctionAPIRequest
+		auction models.Auction
+		teams   []models.Team
+		sold    []models.Player
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind spectator view request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		a.logger.Error("failed to find auction in database", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find auction"})
+		return
+	}
+
+	cursor, err := a.MongoDBClient.Collection("teams").Find(ctx, bson.M{"auction_id": request.AuctionID})
+	if err != nil {
+		a.logger.Error("failed to fetch teams", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &teams); err != nil {
+		a.logger.Error("failed to decode teams", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	// Sales are the players bought by a team, latest first
+	salesFilter := bson.M{
+		"auction_id":   request.AuctionID,
+		"current_team": bson.M{"$nin": bson.A{"", nil}},
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err = a.MongoDBClient.Collection("players").Find(ctx, salesFilter, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch sold players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &sold); err != nil {
+		a.logger.Error("failed to decode sold players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	// Spectators see the squads but not the emails of the members behind them
+	if c.GetString("auction_role") == models.AuctionRoleSpectator {
+		hideTeamOwners(teams, models.AuctionRoleSpectator)
+		for i := range sold {
+			if sold[i].Availability != nil {
+				availability := *sold[i].Availability
+				availability.UpdatedBy = ""
+				sold[i].Availability = &availability
+			}
+		}
+	}
+
+	playersByID := make(map[primitive.ObjectID]models.Player, len(sold))
+	for _, player := range sold {
+		playersByID[player.Id] = player
+	}
+
+	squads := make([]spectatorTeam, 0, len(teams))
+	for _, team := range teams {
+		view := spectatorTeam{Team: team, Players: []models.Player{}}
+		for _, playerID := range team.Squad {
+			if player, ok := playersByID[playerID]; ok {
+				view.Players = append(view.Players, player)
+				view.Spent += player.SellingPrice
+			}
+		}
+		squads = append(squads, view)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Auction fetched successfully",
+		"auction": auctionAPIResp{
+			AuctionID:    auction.ID,
+			AuctionName:  auction.AuctionName,
+			AuctionImage: auction.AuctionImage,
+			AuctionDate:  auction.AuctionDate,
+			IsIPLAuction: auction.IsIPLAuction,
+			Status:       auction.CurrentStatus(),
+		},
+		"teams": squads,
+		"sales": sold,
+	})
+}
@@ -4,9 +4,16 @@ import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -19,14 +26,45 @@ type auctionAPIResp struct {
 	AuctionID    primitive.ObjectID `json:"id"`
 	AuctionName  string             `json:"auction_name"`
 	AuctionImage string             `json:"auction_image"`
+	AuctionDate  time.Time          `json:"auction_date"`
 	IsIPLAuction bool               `json:"is_ipl_auction"`
+	Status       string             `json:"status"`
+}
+
+// auctionListPage is one page of the auction listing, as returned and cached
+type auctionListPage struct {
+	Auctions   []auctionAPIResp `json:"auctions"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+// auctionListQuery is the normalized query of the auction listing
+type auctionListQuery struct {
+	Type   string
+	Search string
+	Status string
+	From   time.Time
+	To     time.Time
+	Sort   string
+	Order  int
+	Limit  int64
+	Cursor string
+}
+
+// auctionCursor is the position after the last auction of a page
+type auctionCursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// auctionSortFields maps the accepted sort options to their document fields
+var auctionSortFields = map[string]string{
+	"created_at":   "created_at",
+	"auction_date": "auction_date",
+	"name":         "auction_name",
 }
 
 func (a *API) GetAllAuctionsController(c *gin.Context) {
-	var (
-		auctions []models.Auction
-		resp     []auctionAPIResp
-	)
+	var auctions []models.Auction
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
 	defer cancel()
@@ -39,16 +77,28 @@ func (a *API) GetAllAuctionsController(c *gin.Context) {
 	}
 
 	auctionType := c.DefaultQuery("type", "all") // all | create | join
-	auctionsKey := fmt.Sprintf(auctionCacheKey, auctionType, email)
+	if auctionType != "all" && auctionType != "create" && auctionType != "join" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query type"})
+		return
+	}
+
+	query, err := parseAuctionListQuery(c, auctionType)
+	if err != nil {
+		a.logger.Warn("invalid auction list query", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	auctionsKey := fmt.Sprintf(auctionCacheKey, query.Type, email) + ":" + query.normalize()
 
 	// Try to get from cache first
 	val, err := a.RedisClient.Get(ctx, auctionsKey).Result()
 	if err == nil {
-		var cachedAuctions []models.Auction
-		if err = json.Unmarshal([]byte(val), &cachedAuctions); err == nil {
+		var cachedPage auctionListPage
+		if err = json.Unmarshal([]byte(val), &cachedPage); err == nil {
 			c.JSON(http.StatusOK, gin.H{
-				"message":  "Auctions fetched successfully from cache",
-				"auctions": cachedAuctions,
+				"message":     "Auctions fetched successfully from cache",
+				"auctions":    cachedPage.Auctions,
+				"next_cursor": cachedPage.NextCursor,
 			})
 			return
 		} else {
@@ -60,24 +110,16 @@ func (a *API) GetAllAuctionsController(c *gin.Context) {
 		}
 	}
 
-	var filter bson.M
-	switch auctionType {
-	case "create":
-		filter = bson.M{"created_by": email}
-	case "join":
-		filter = bson.M{"joined_by": email}
-	case "all":
-		filter = bson.M{
-			"$or": []bson.M{
-				{"created_by": email},
-				{"joined_by": email},
-			},
-		}
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query type"})
+	filter, err := query.filter(email)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
 		return
 	}
-	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	// One extra auction tells whether another page exists
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: query.Sort, Value: query.Order}, {Key: "_id", Value: query.Order}}).
+		SetLimit(query.Limit + 1)
 
 	cursor, err := a.MongoDBClient.Collection("auctions").Find(ctx, filter, findOptions)
 	if err != nil {
@@ -93,20 +135,33 @@ func (a *API) GetAllAuctionsController(c *gin.Context) {
 		return
 	}
 
+	page := auctionListPage{Auctions: []auctionAPIResp{}}
+	if int64(len(auctions)) > query.Limit {
+		auctions = auctions[:query.Limit]
+		page.NextCursor = query.encodeCursor(auctions[len(auctions)-1])
+	}
+
 	for _, auction := range auctions {
 		var auctionResp auctionAPIResp
 		auctionResp.AuctionID = auction.ID
 		auctionResp.AuctionName = auction.AuctionName
 		auctionResp.AuctionImage = auction.AuctionImage
+		auctionResp.AuctionDate = auction.AuctionDate
 		auctionResp.IsIPLAuction = auction.IsIPLAuction
-		resp = append(resp, auctionResp)
+		auctionResp.Status = auction.CurrentStatus()
+		page.Auctions = append(page.Auctions, auctionResp)
 	}
 
-	// Cache the results
-	if len(resp) > 0 {
-		jsonData, err := json.Marshal(resp)
+	// Cache the results, the key is indexed per user so writes can clear every query variant
+	if len(page.Auctions) > 0 {
+		jsonData, err := json.Marshal(page)
 		if err == nil {
-			if err = a.RedisClient.Set(ctx, auctionsKey, jsonData, TTLTime).Err(); err != nil {
+			indexKey := fmt.Sprintf(auctionCacheIndexKey, email)
+			pipe := a.RedisClient.TxPipeline()
+			pipe.Set(ctx, auctionsKey, jsonData, TTLTime)
+			pipe.SAdd(ctx, indexKey, auctionsKey)
+			pipe.Expire(ctx, indexKey, TTLTime)
+			if _, err = pipe.Exec(ctx); err != nil {
 				a.logger.Warn("failed to set auctions in redis", zap.Error(err))
 			}
 		} else {
@@ -115,7 +170,217 @@ func (a *API) GetAllAuctionsController(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":  "Auctions fetched successfully",
-		"auctions": resp,
+		"message":     "Auctions fetched successfully",
+		"auctions":    page.Auctions,
+		"next_cursor": page.NextCursor,
 	})
 }
+
+// parseAuctionListQuery reads and validates the query params of the auction listing
+func parseAuctionListQuery(c *gin.Context, auctionType string) (query auctionListQuery, err error) {
+	query = auctionListQuery{
+		Type:   auctionType,
+		Search: strings.ToLower(strings.TrimSpace(c.Query("search"))),
+		Status: c.Query("status"),
+		Sort:   c.DefaultQuery("sort", "created_at"),
+		Order:  -1,
+		Limit:  DefaultPageSize,
+		Cursor: c.Query("cursor"),
+	}
+
+	if query.Status != "" && !models.ValidAuctionStatus(query.Status) {
+		return query, errors.New("Invalid status")
+	}
+
+	field, ok := auctionSortFields[query.Sort]
+	if !ok {
+		return query, errors.New("Invalid sort option")
+	}
+	query.Sort = field
+
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		query.Order = 1
+	case "desc":
+		query.Order = -1
+	default:
+		return query, errors.New("Invalid sort order")
+	}
+
+	if value := c.Query("limit"); value != "" {
+		if query.Limit, err = strconv.ParseInt(value, 10, 64); err != nil || query.Limit <= 0 {
+			return query, errors.New("Invalid limit")
+		}
+		if query.Limit > MaxPageSize {
+			query.Limit = MaxPageSize
+		}
+	}
+
+	if query.From, err = parseDateParam(c.Query("from")); err != nil {
+		return query, errors.New("Invalid from date")
+	}
+	if query.To, err = parseDateParam(c.Query("to")); err != nil {
+		return query, errors.New("Invalid to date")
+	}
+
+	return query, nil
+}
+
+// parseDateParam accepts RFC3339 timestamps or plain dates
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// normalize returns a stable representation of the query used in cache keys
+func (q auctionListQuery) normalize() string {
+	values := url.Values{}
+	values.Set("search", q.Search)
+	values.Set("status", q.Status)
+	values.Set("sort", q.Sort)
+	values.Set("order", strconv.Itoa(q.Order))
+	values.Set("limit", strconv.FormatInt(q.Limit, 10))
+	values.Set("cursor", q.Cursor)
+	if !q.From.IsZero() {
+		values.Set("from", q.From.UTC().Format(time.RFC3339))
+	}
+	if !q.To.IsZero() {
+		values.Set("to", q.To.UTC().Format(time.RFC3339))
+	}
+	return values.Encode() // Encode sorts by key
+}
+
+// filter builds the mongo filter of the query for the given user
+func (q auctionListQuery) filter(email string) (bson.M, error) {
+	var conditions []bson.M
+
+	switch q.Type {
+	case "create":
+		conditions = append(conditions, bson.M{"created_by": email})
+	case "join":
+		conditions = append(conditions, bson.M{"joined_by": email})
+	case "all":
+		conditions = append(conditions, bson.M{
+			"$or": []bson.M{
+				{"created_by": email},
+				{"joined_by": email},
+			},
+		})
+	case "public":
+		conditions = append(conditions, bson.M{"is_public": true})
+		if q.Status == "" {
+			conditions = append(conditions, bson.M{"status": bson.M{"$in": discoverableStatuses}})
+		}
+	}
+	conditions = append(conditions, bson.M{"deleted_at": bson.M{"$exists": false}})
+
+	if q.Search != "" {
+		conditions = append(conditions, bson.M{
+			"auction_name": primitive.Regex{Pattern: regexp.QuoteMeta(q.Search), Options: "i"},
+		})
+	}
+	if q.Status == models.AuctionStatusDraft {
+		// Auctions created before statuses existed are drafts
+		conditions = append(conditions, bson.M{"status": bson.M{"$in": bson.A{q.Status, nil}}})
+	} else if q.Status != "" {
+		conditions = append(conditions, bson.M{"status": q.Status})
+	}
+
+	dateRange := bson.M{}
+	if !q.From.IsZero() {
+		dateRange["$gte"] = q.From
+	}
+	if !q.To.IsZero() {
+		dateRange["$lte"] = q.To
+	}
+	if len(dateRange) > 0 {
+		conditions = append(conditions, bson.M{"auction_date": dateRange})
+	}
+
+	if q.Cursor != "" {
+		after, err := q.decodeCursor()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, after)
+	}
+
+	return bson.M{"$and": conditions}, nil
+}
+
+// encodeCursor returns the opaque cursor pointing after the given auction
+func (q auctionListQuery) encodeCursor(auction models.Auction) string {
+	cursor := auctionCursor{ID: auction.ID.Hex()}
+	switch q.Sort {
+	case "auction_name":
+		cursor.Value = auction.AuctionName
+	case "auction_date":
+		cursor.Value = auction.AuctionDate.UTC().Format(time.RFC3339Nano)
+	default:
+		cursor.Value = auction.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the filter matching the auctions after the cursor
+func (q auctionListQuery) decodeCursor() (bson.M, error) {
+	var cursor auctionCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any = cursor.Value
+	if q.Sort != "auction_name" {
+		if value, err = time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	op := "$lt"
+	if q.Order == 1 {
+		op = "$gt"
+	}
+	return bson.M{
+		"$or": []bson.M{
+			{q.Sort: bson.M{op: value}},
+			{q.Sort: value, "_id": bson.M{op: id}},
+		},
+	}, nil
+}
+
+// clearAuctionListCache deletes every cached auction list of the given users
+func (a *API) clearAuctionListCache(ctx context.Context, emails ...string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	var cacheKeys []string
+	for _, email := range emails {
+		indexKey := fmt.Sprintf(auctionCacheIndexKey, email)
+		queryKeys, err := a.RedisClient.SMembers(ctx, indexKey).Result()
+		if err != nil {
+			return err
+		}
+		cacheKeys = append(cacheKeys, queryKeys...)
+		cacheKeys = append(cacheKeys, indexKey)
+	}
+	_, err := a.RedisClient.Del(ctx, cacheKeys...).Result()
+	return err
+}
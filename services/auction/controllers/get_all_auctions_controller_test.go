@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"auction-web/pkg/models"
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAuctionCursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, 3, 14, 9, 30, 15, 123456789, time.UTC)
+	date := time.Date(2026, 4, 1, 18, 0, 0, 0, time.FixedZone("IST", 5*60*60+30*60))
+	auction := models.Auction{
+		ID:          primitive.NewObjectID(),
+		AuctionName: "IPL Mega Auction",
+		AuctionDate: date,
+		CreatedAt:   created,
+	}
+
+	tests := []struct {
+		name  string
+		sort  string
+		order int
+		value any
+		op    string
+	}{
+		{name: "created at descending", sort: "created_at", order: -1, value: created, op: "$lt"},
+		{name: "created at ascending", sort: "created_at", order: 1, value: created, op: "$gt"},
+		{name: "auction date in utc", sort: "auction_date", order: -1, value: date.UTC(), op: "$lt"},
+		{name: "auction name", sort: "auction_name", order: 1, value: "IPL Mega Auction", op: "$gt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := auctionListQuery{Sort: tt.sort, Order: tt.order}
+			query.Cursor = query.encodeCursor(auction)
+
+			got, err := query.decodeCursor()
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			want := bson.M{
+				"$or": []bson.M{
+					{tt.sort: bson.M{tt.op: tt.value}},
+					{tt.sort: tt.value, "_id": bson.M{tt.op: auction.ID}},
+				},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("decodeCursor() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		sort   string
+		cursor string
+	}{
+		{name: "not base64", sort: "created_at", cursor: "%%%"},
+		{name: "not json", sort: "created_at", cursor: encode("cursor")},
+		{name: "invalid id", sort: "auction_name", cursor: encode(`{"id":"abc","v":"x"}`)},
+		{name: "invalid time", sort: "created_at", cursor: encode(`{"id":"65f2a1b0c3d4e5f60718293a","v":"yesterday"}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := auctionListQuery{Sort: tt.sort, Order: -1, Cursor: tt.cursor}
+			if _, err := query.decodeCursor(); err == nil {
+				t.Error("decodeCursor() error = nil, want error")
+			}
+		})
+	}
+}
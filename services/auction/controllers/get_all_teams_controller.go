@@ -40,6 +40,7 @@ func (a *API) GetAllTeamsController(c *gin.Context) {
 	if err == nil {
 		var cachedTeams []models.Team
 		if err = json.Unmarshal([]byte(val), &cachedTeams); err == nil {
+			hideTeamOwners(cachedTeams, c.GetString("auction_role"))
 			c.JSON(http.StatusOK, gin.H{
 				"message": "Teams fetched successfully from cache",
 				"teams":   cachedTeams,
@@ -81,8 +82,19 @@ func (a *API) GetAllTeamsController(c *gin.Context) {
 		}
 	}
 
+	hideTeamOwners(teams, c.GetString("auction_role"))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Teams fetched successfully",
 		"teams":   teams,
 	})
 }
+
+// hideTeamOwners removes the emails of the team owners from teams shown to a spectator
+func hideTeamOwners(teams []models.Team, role string) {
+	if role != models.AuctionRoleSpectator {
+		return
+	}
+	for i := range teams {
+		teams[i].TeamOwners = []string{}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"auction-web/pkg/models"
 	"context"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -54,12 +55,12 @@ func (a *API) GetAuctionController(c *gin.Context) {
 	}
 
 	var auction models.Auction
-	filter := bson.M{"_id": request.AuctionID}
+	filter := bson.M{"_id": request.AuctionID, "deleted_at": bson.M{"$exists": false}}
 	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, filter).Decode(&auction)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			a.logger.Warn("no auction found", zap.Error(err), zap.Any("auction_id", request.AuctionID))
-			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found or you are not authorized to update it"})
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
 			return
 		}
 		a.logger.Error("failed to find auction in database", zap.Error(err))
@@ -67,6 +68,14 @@ func (a *API) GetAuctionController(c *gin.Context) {
 		return
 	}
 
+	// Participants, requests and bans are only shown to the admins of the auction
+	if !slices.Contains(models.AuctionAdminRoles, c.GetString("auction_role")) {
+		auction.JoinRequests = nil
+		auction.CoAdmins = nil
+		auction.BannedUsers = nil
+		auction.JoinedBy = []string{}
+	}
+
 	userNames, err := database.FetchRecords[userTeam](ctx, a.PostgresClient, userQuery, auction.JoinedBy)
 	if err != nil {
 		a.logger.Error("failed to fetch user name from database", zap.Error(err))
@@ -80,6 +89,14 @@ func (a *API) GetAuctionController(c *gin.Context) {
 	response.CreatedBy = auction.CreatedBy
 	response.AuctionDate = auction.AuctionDate
 	response.IsIPLAuction = auction.IsIPLAuction
+	response.Status = auction.CurrentStatus()
+	response.IsPrivate = auction.IsPrivate
+	response.IsPublic = auction.IsPublic
+	response.RequiresApproval = auction.RequiresApproval
+	response.MaxParticipants = auction.MaxParticipants
+	response.JoinRequests = auction.JoinRequests
+	response.CoAdmins = auction.CoAdmins
+	response.BannedUsers = auction.BannedUsers
 	response.CreatedAt = auction.CreatedAt
 	response.UpdatedAt = auction.UpdatedAt
 	response.JoinedBy = append(response.JoinedBy, auction.JoinedBy...)
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type inviteAuctionRequest struct {
+	AuctionID      primitive.ObjectID `json:"auction_id" binding:"required"`
+	ExpiresInHours int                `json:"expires_in_hours"`
+}
+
+// CreateInviteController generates an expiring invite code for an auction owned by the caller
+func (a *API) CreateInviteController(c *gin.Context) {
+	var request inviteAuctionRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind create invite request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if email == "" {
+		a.logger.Error("failed to fetch email from token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+		return
+	}
+
+	count, err := a.MongoDBClient.Collection("auctions").CountDocuments(ctx, bson.M{
+		"_id":        request.AuctionID,
+		"created_by": email,
+		"deleted_at": bson.M{"$exists": false},
+	})
+	if err != nil {
+		a.logger.Error("failed to find auction for invite", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found or you are not authorized to invite"})
+		return
+	}
+
+	expiresIn := InviteTTL
+	if request.ExpiresInHours > 0 {
+		expiresIn = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		a.logger.Error("failed to generate invite code", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate invite"})
+		return
+	}
+
+	invite := models.AuctionInvite{
+		Code:      code,
+		AuctionID: request.AuctionID,
+		CreatedBy: email,
+		ExpiresAt: time.Now().Add(expiresIn),
+		CreatedAt: time.Now(),
+	}
+	if _, err = a.MongoDBClient.Collection("auction_invites").InsertOne(ctx, invite); err != nil {
+		a.logger.Error("failed to save invite", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invite created successfully",
+		"invite":  invite,
+	})
+}
+
+// generateInviteCode returns a random url safe invite code
+func generateInviteCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
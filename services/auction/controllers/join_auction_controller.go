@@ -5,23 +5,26 @@ import (
 	"auction-web/pkg/models"
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
 type joinAuctionRequest struct {
-	AuctionID primitive.ObjectID `json:"auction_id"`
+	AuctionID  primitive.ObjectID `json:"auction_id"`
+	InviteCode string             `json:"invite_code"`
 }
 
 func (a *API) JoinAuctionController(c *gin.Context) {
 	var (
 		request  joinAuctionRequest
+		auction  models.Auction
 		response models.Auction
 	)
 
@@ -41,38 +44,88 @@ func (a *API) JoinAuctionController(c *gin.Context) {
 		return
 	}
 
-	// First check if user is already joined
-	alreadyJoinedFilter := bson.M{
-		"_id":       request.AuctionID,
-		"joined_by": email,
+	// An invite code identifies the auction on its own
+	if request.InviteCode != "" {
+		var invite models.AuctionInvite
+		err := a.MongoDBClient.Collection("auction_invites").FindOne(ctx, bson.M{"_id": request.InviteCode}).Decode(&invite)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invite code"})
+				return
+			}
+			a.logger.Error("failed to find invite", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+		if time.Now().After(invite.ExpiresAt) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invite code has expired"})
+			return
+		}
+		if !request.AuctionID.IsZero() && request.AuctionID != invite.AuctionID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invite code does not belong to this auction"})
+			return
+		}
+		request.AuctionID = invite.AuctionID
 	}
 
-	count, err := a.MongoDBClient.Collection("auctions").CountDocuments(ctx, alreadyJoinedFilter)
+	filter := bson.M{"_id": request.AuctionID, "deleted_at": bson.M{"$exists": false}}
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, filter).Decode(&auction)
 	if err != nil {
-		a.logger.Error("failed to check if user already joined", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			a.logger.Warn("invalid auction id", zap.Any("object_id", request.AuctionID))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Auction not found"})
+			return
+		}
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
 		return
 	}
 
-	if count > 0 {
+	switch {
+	case auction.CreatedBy == email:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You have created this auction"})
+		return
+	case containsEmail(auction.BannedUsers, email):
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are banned from this auction"})
+		return
+	case containsEmail(auction.JoinedBy, email):
 		c.JSON(http.StatusBadRequest, gin.H{"error": "You have already joined this auction"})
 		return
+	case containsEmail(auction.JoinRequests, email):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Your join request is pending approval"})
+		return
+	case auction.IsPrivate && request.InviteCode == "":
+		c.JSON(http.StatusForbidden, gin.H{"error": "This auction can only be joined with an invite code"})
+		return
+	case auction.MaxParticipants > 0 && len(auction.JoinedBy) >= auction.MaxParticipants:
+		c.JSON(http.StatusConflict, gin.H{"error": "Auction is full"})
+		return
 	}
 
-	filter := bson.M{
-		"_id": request.AuctionID,
+	// Join requests wait in the approval queue of the creator
+	if auction.RequiresApproval {
+		update := bson.M{"$addToSet": bson.M{"join_requests": email}}
+		if _, err = a.MongoDBClient.Collection("auctions").UpdateByID(ctx, auction.ID, update); err != nil {
+			a.logger.Error("failed to request to join auction", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Join request sent for approval",
+		})
+		return
 	}
-	updateQuery := bson.M{
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = a.MongoDBClient.Collection("auctions").FindOneAndUpdate(ctx, participantCapFilter(auction), bson.M{
 		"$addToSet": bson.M{
-			"joined_by": email, // Changed from object to simple string
+			"joined_by": email,
 		},
-	}
-
-	err = a.MongoDBClient.Collection("auctions").FindOneAndUpdate(ctx, filter, updateQuery).Decode(&response)
+	}, opts).Decode(&response)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			a.logger.Warn("invalid auction id", zap.Any("object_id", request.AuctionID))
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Auction not found"})
+			c.JSON(http.StatusConflict, gin.H{"error": "Auction is full"})
 			return
 		}
 
@@ -82,12 +135,7 @@ func (a *API) JoinAuctionController(c *gin.Context) {
 	}
 
 	// Clear cache for all auction types for this user
-	cacheKeys := []string{
-		fmt.Sprintf(auctionCacheKey, "create", email),
-		fmt.Sprintf(auctionCacheKey, "join", email),
-		fmt.Sprintf(auctionCacheKey, "all", email),
-	}
-	if _, err = a.RedisClient.Del(ctx, cacheKeys...).Result(); err != nil {
+	if err = a.clearAuctionListCache(ctx, email); err != nil {
 		a.logger.Error("failed to delete join auctions from cache", zap.Error(err))
 	}
 
@@ -96,3 +144,24 @@ func (a *API) JoinAuctionController(c *gin.Context) {
 		"auction": response,
 	})
 }
+
+// participantCapFilter matches the auction only while it has room for another participant
+func participantCapFilter(auction models.Auction) bson.M {
+	filter := bson.M{"_id": auction.ID}
+	if auction.MaxParticipants > 0 {
+		filter["$expr"] = bson.M{
+			"$lt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$joined_by", bson.A{}}}}, auction.MaxParticipants},
+		}
+	}
+	return filter
+}
+
+// containsEmail reports whether the email is present in the list
+func containsEmail(emails []string, email string) bool {
+	for _, e := range emails {
+		if e == email {
+			return true
+		}
+	}
+	return false
+}
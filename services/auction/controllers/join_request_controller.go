@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type joinDecisionRequest struct {
+	AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	Email     string             `json:"email" binding:"required"`
+}
+
+// JoinRequestController returns the handler approving or rejecting a pending join request
+func (a *API) JoinRequestController(approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			request  joinDecisionRequest
+			auction  models.Auction
+			response models.Auction
+		)
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+		defer cancel()
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			a.logger.Error("failed to bind join decision request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+
+		// The owner and co-admins were authorized by the admins middleware
+		filter := bson.M{
+			"_id":           request.AuctionID,
+			"deleted_at":    bson.M{"$exists": false},
+			"join_requests": request.Email,
+		}
+		err := a.MongoDBClient.Collection("auctions").FindOne(ctx, filter).Decode(&auction)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Join request not found"})
+				return
+			}
+			a.logger.Error("failed to find auction for join request", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+
+		update := bson.M{
+			"$pull": bson.M{"join_requests": request.Email},
+			"$set":  bson.M{"updated_at": time.Now()},
+		}
+		if approve {
+			filter = participantCapFilter(auction)
+			filter["join_requests"] = request.Email
+			update["$addToSet"] = bson.M{"joined_by": request.Email}
+		}
+
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = a.MongoDBClient.Collection("auctions").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Auction is full"})
+				return
+			}
+			a.logger.Error("failed to review join request", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+
+		message := "Join request rejected"
+		if approve {
+			message = "Join request approved"
+			if err = a.clearAuctionListCache(ctx, request.Email); err != nil {
+				a.logger.Error("failed to delete join auctions from cache", zap.Error(err))
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": message,
+			"auction": response,
+		})
+	}
+}
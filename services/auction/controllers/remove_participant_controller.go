@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type removeParticipantRequest struct {
+	AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	Email     string             `json:"email" binding:"required"`
+	Ban       bool               `json:"ban"`
+}
+
+// LeaveAuctionController removes the caller from an auction they joined
+func (a *API) LeaveAuctionController(c *gin.Context) {
+	var request auctionAPIRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind leave auction request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if email == "" {
+		a.logger.Error("failed to fetch email from token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+		return
+	}
+
+	err := a.detachParticipant(ctx, request.AuctionID, email, false)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "You are not a participant of this auction"})
+			return
+		}
+		a.logger.Error("failed to leave auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Successfully left the auction",
+	})
+}
+
+// RemoveParticipantController lets an admin remove, and optionally ban, a participant
+func (a *API) RemoveParticipantController(c *gin.Context) {
+	var (
+		request removeParticipantRequest
+		auction models.Auction
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind remove participant request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		a.logger.Error("failed to find auction in database", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find auction"})
+		return
+	}
+
+	// Co-admins may remove participants but not the owner or other co-admins
+	if request.Email == auction.CreatedBy ||
+		(containsEmail(auction.CoAdmins, request.Email) && c.GetString("auction_role") != models.AuctionRoleOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to remove this participant"})
+		return
+	}
+
+	err = a.detachParticipant(ctx, request.AuctionID, request.Email, request.Ban)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+			return
+		}
+		a.logger.Error("failed to remove participant", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	message := "Participant removed successfully"
+	if request.Ban {
+		message = "Participant removed and banned successfully"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+	})
+}
+
+// UnbanParticipantController lifts the ban of a user so they can join again
+func (a *API) UnbanParticipantController(c *gin.Context) {
+	var request removeParticipantRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind unban participant request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	filter := bson.M{
+		"_id":          request.AuctionID,
+		"banned_users": request.Email,
+	}
+	update := bson.M{
+		"$pull": bson.M{"banned_users": request.Email},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	res, err := a.MongoDBClient.Collection("auctions").UpdateOne(ctx, filter, update)
+	if err != nil {
+		a.logger.Error("failed to unban participant", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if res.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User is not banned from this auction"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Participant unbanned successfully",
+	})
+}
+
+// detachParticipant pulls the user out of the auction and its team owners,
+// then clears the caches listing them
+func (a *API) detachParticipant(ctx context.Context, auctionID primitive.ObjectID, email string, ban bool) error {
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// The auction as it was before the user left, the cache of everyone listing it is stale
+	var auction models.Auction
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		filter := bson.M{
+			"_id": auctionID,
+			"$or": []bson.M{
+				{"joined_by": email},
+				{"join_requests": email},
+			},
+		}
+		if ban {
+			// Banning also applies to users who are not participants yet
+			delete(filter, "$or")
+		}
+
+		update := bson.M{
+			"$pull": bson.M{
+				"joined_by":     email,
+				"co_admins":     email,
+				"join_requests": email,
+			},
+			"$set": bson.M{"updated_at": time.Now()},
+		}
+		if ban {
+			update["$addToSet"] = bson.M{"banned_users": email}
+		}
+
+		err := a.MongoDBClient.Collection("auctions").FindOneAndUpdate(sessCtx, filter, update).Decode(&auction)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = a.MongoDBClient.Collection("teams").UpdateMany(sessCtx,
+			bson.M{"auction_id": auctionID, "team_owners": email},
+			bson.M{
+				"$pull": bson.M{"team_owners": email},
+				"$set":  bson.M{"updated_at": time.Now()},
+			},
+		)
+		return nil, err
+	})
+	if err != nil {
+		return err
+	}
+
+	members := append([]string{auction.CreatedBy, email}, auction.JoinedBy...)
+	members = append(members, auction.CoAdmins...)
+	if err = a.clearAuctionListCache(ctx, members...); err != nil {
+		a.logger.Error("failed to delete auctions from cache", zap.Error(err))
+	}
+	if _, err = a.RedisClient.Del(ctx, fmt.Sprintf(teamCacheKey, auctionID)).Result(); err != nil {
+		a.logger.Error("failed to delete teams from cache", zap.Error(err))
+	}
+	return nil
+}
@@ -3,8 +3,8 @@ package controllers
 import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -30,6 +30,11 @@ func (a *API) UpdateAuctionController(c *gin.Context) {
 		return
 	}
 
+	if request.IsPublic && request.IsPrivate {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Auction cannot be both public and private"})
+		return
+	}
+
 	email := c.GetString("email")
 	if email == "" {
 		a.logger.Error("failed to fetch email from token")
@@ -37,17 +42,28 @@ func (a *API) UpdateAuctionController(c *gin.Context) {
 		return
 	}
 
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpUpdate, request.ID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.ID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
 	filter := bson.M{
 		"_id":        request.ID,
 		"created_by": email,
 	}
 	update := bson.M{
 		"$set": bson.M{
-			"auction_name":   request.AuctionName,
-			"auction_image":  request.AuctionImage,
-			"auction_date":   request.AuctionDate,
-			"is_ipl_auction": request.IsIPLAuction,
-			"updated_at":     time.Now(),
+			"auction_name":      request.AuctionName,
+			"auction_image":     request.AuctionImage,
+			"auction_date":      request.AuctionDate,
+			"is_ipl_auction":    request.IsIPLAuction,
+			"is_private":        request.IsPrivate,
+			"is_public":         request.IsPublic,
+			"requires_approval": request.RequiresApproval,
+			"max_participants":  request.MaxParticipants,
+			"updated_at":        time.Now(),
 		},
 	}
 
@@ -65,12 +81,7 @@ func (a *API) UpdateAuctionController(c *gin.Context) {
 	}
 
 	// If auction is updated, we need to delete old data from cache
-	cacheKeys := []string{
-		fmt.Sprintf(auctionCacheKey, "create", email),
-		fmt.Sprintf(auctionCacheKey, "join", email),
-		fmt.Sprintf(auctionCacheKey, "all", email),
-	}
-	if _, err = a.RedisClient.Del(ctx, cacheKeys...).Result(); err != nil {
+	if err = a.clearAuctionListCache(ctx, append([]string{response.CreatedBy}, response.JoinedBy...)...); err != nil {
 		a.logger.Error("failed to delete auctions from cache", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from redis"})
 		return
@@ -3,6 +3,7 @@ package controllers
 import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
 	"fmt"
 	"net/http"
@@ -30,19 +31,31 @@ func (a *API) UpdateTeamController(c *gin.Context) {
 		return
 	}
 
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManageTeams, request.AuctionId); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionId))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
 	filter := bson.M{
 		"_id":        request.ID,
 		"auction_id": request.AuctionId,
 	}
-	update := bson.M{
-		"$set": bson.M{
-			"team_name":   request.TeamName,
-			"team_image":  request.TeamImage,
-			"team_owners": request.TeamOwners,
-			"updated_at":  time.Now(),
-		},
+	set := bson.M{
+		"team_name":   request.TeamName,
+		"team_image":  request.TeamImage,
+		"team_owners": request.TeamOwners,
+		"updated_at":  time.Now(),
 	}
 
+	// Team owners can only edit their own team and cannot change its owners
+	if c.GetString("auction_role") == models.AuctionRoleTeamOwner {
+		filter["team_owners"] = c.GetString("email")
+		delete(set, "team_owners")
+	}
+	update := bson.M{"$set": set}
+
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	err := a.MongoDBClient.Collection("teams").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
 	if err != nil {
@@ -57,8 +70,11 @@ func (a *API) UpdateTeamController(c *gin.Context) {
 	}
 
 	// If team is updated, we need to delete old data from cache
-	cacheKeys := fmt.Sprintf(teamCacheKey, request.AuctionId)
-	if _, err = a.RedisClient.Del(ctx, cacheKeys).Result(); err != nil {
+	cacheKeys := []string{
+		fmt.Sprintf(teamCacheKey, request.AuctionId),
+		fmt.Sprintf(leaderboardKey, request.AuctionId.Hex()),
+	}
+	if _, err = a.RedisClient.Del(ctx, cacheKeys...).Result(); err != nil {
 		a.logger.Error("failed to delete teams from cache", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from redis"})
 		return
@@ -28,5 +28,10 @@ func main() {
 	defer api.RedisClient.Close()
 	api.RegisterRoutes(router)
 
+	// Background purge of soft deleted auctions runs for the server lifetime
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go api.PurgeDeletedAuctions(purgeCtx)
+
 	utils.StartServer(ctx, router, "auction", "7003")
 }
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// errPlayerReplaced is returned when another request replaced the player first
+var errPlayerReplaced = errors.New("player already replaced")
+
+// SetAvailabilityController reports a player as injured, doubtful or withdrawn, or
+// back to available, with an optional note and expected return date
+func (a *API) SetAvailabilityController(c *gin.Context) {
+	var (
+		request struct {
+			PlayerID       primitive.ObjectID `json:"player_id" binding:"required"`
+			Status         string             `json:"status" binding:"required"`
+			Note           string             `json:"note"`
+			ExpectedReturn *time.Time         `json:"expected_return"`
+		}
+		player models.Player
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind availability request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if !models.ValidAvailability(request.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid availability status"})
+		return
+	}
+	if request.Status == models.AvailabilityReplaced {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Register a replacement to mark a player as replaced"})
+		return
+	}
+
+	if !a.findAvailabilityPlayer(ctx, c, request.PlayerID, &player) {
+		return
+	}
+
+	if player.AvailabilityStatus() == models.AvailabilityReplaced {
+		c.JSON(http.StatusConflict, gin.H{"error": "Player " + player.PlayerName + " was already replaced"})
+		return
+	}
+
+	update := bson.M{
+		"$unset": bson.M{"availability": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+	if request.Status != models.AvailabilityAvailable {
+		availability := models.Availability{
+			Status:    request.Status,
+			Note:      request.Note,
+			UpdatedBy: c.GetString("email"),
+			UpdatedAt: time.Now(),
+		}
+		// A return date only makes sense while the player is expected back
+		if request.Status != models.AvailabilityWithdrawn && request.ExpectedReturn != nil {
+			expected := request.ExpectedReturn.UTC()
+			availability.ExpectedReturn = &expected
+		}
+		update = bson.M{"$set": bson.M{"availability": availability, "updated_at": time.Now()}}
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := a.MongoDBClient.Collection("players").FindOneAndUpdate(ctx, bson.M{"_id": player.Id}, update, opts).Decode(&player)
+	if err != nil {
+		a.logger.Error("failed to update availability", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update availability"})
+		return
+	}
+
+	if err = a.clearPlayerCache(ctx, player.AuctionId); err != nil {
+		a.logger.Warn("failed to clear player cache after availability update", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Availability updated successfully",
+		"player":  player,
+	})
+}
+
+// RegisterReplacementController adds the replacement of a withdrawn player to the
+// auction. The replacement joins the squad owning the player it replaces, which stays
+// in the squad marked as replaced so the points it already scored are kept.
+func (a *API) RegisterReplacementController(c *gin.Context) {
+	var (
+		request struct {
+			PlayerID    primitive.ObjectID `json:"player_id" binding:"required"`
+			Note        string             `json:"note"`
+			Replacement struct {
+				CatalogID  primitive.ObjectID `json:"catalog_id"`
+				PlayerName string             `json:"player_name" binding:"required"`
+				Country    string             `json:"country"`
+				Role       string             `json:"role" binding:"required"`
+				IPLTeam    string             `json:"ipl_team"`
+				BasePrice  float64            `json:"base_price"`
+			} `json:"replacement" binding:"required"`
+		}
+		player  models.Player
+		auction models.Auction
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind replacement request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if !models.ValidPlayerRole(request.Replacement.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player role"})
+		return
+	}
+
+	if !a.findAvailabilityPlayer(ctx, c, request.PlayerID, &player) {
+		return
+	}
+
+	if player.AvailabilityStatus() == models.AvailabilityReplaced {
+		c.JSON(http.StatusConflict, gin.H{"error": "Player " + player.PlayerName + " was already replaced"})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": player.AuctionId}).Decode(&auction)
+	if err != nil {
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	playerNumber, err := a.nextPlayerNumber(ctx, player.AuctionId)
+	if err != nil {
+		a.logger.Error("failed to fetch next player number", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	now := time.Now()
+	replacement := models.Player{
+		Id:             primitive.NewObjectID(),
+		AuctionId:      player.AuctionId,
+		CatalogId:      request.Replacement.CatalogID,
+		PlayerNumber:   playerNumber,
+		PlayerName:     request.Replacement.PlayerName,
+		Country:        request.Replacement.Country,
+		Role:           request.Replacement.Role,
+		IPLTeam:        request.Replacement.IPLTeam,
+		BasePrice:      request.Replacement.BasePrice,
+		CurrentTeam:    player.CurrentTeam,
+		Hammer:         player.Hammer,
+		Bids:           []models.Bids{},
+		ReplacementFor: player.Id,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	note := request.Note
+	if note == "" {
+		note = fmt.Sprintf("Replaced by %s", replacement.PlayerName)
+	}
+	availability := models.Availability{
+		Status:     models.AvailabilityReplaced,
+		Note:       note,
+		ReplacedBy: replacement.Id,
+		UpdatedBy:  c.GetString("email"),
+		UpdatedAt:  now,
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		// Only one of concurrent replacements of the player can mark it replaced
+		filter := bson.M{"_id": player.Id, "availability.status": bson.M{"$ne": models.AvailabilityReplaced}}
+		update := bson.M{"$set": bson.M{"availability": availability, "updated_at": now}}
+		marked, err := a.MongoDBClient.Collection("players").UpdateOne(sessCtx, filter, update)
+		if err != nil {
+			return nil, err
+		}
+		if marked.MatchedCount == 0 {
+			return nil, errPlayerReplaced
+		}
+
+		if auction.IsIPLAuction {
+			match := models.Match{
+				Id:        primitive.NewObjectID(),
+				AuctionID: player.AuctionId,
+				Matches:   []int{},
+			}
+			if _, err := a.MongoDBClient.Collection("matches").InsertOne(sessCtx, match); err != nil {
+				return nil, err
+			}
+			replacement.Match = match.Id
+		}
+
+		if _, err := a.MongoDBClient.Collection("players").InsertOne(sessCtx, replacement); err != nil {
+			return nil, err
+		}
+
+		teamFilter := bson.M{"auction_id": player.AuctionId, "squad": player.Id}
+		teamUpdate := bson.M{"$addToSet": bson.M{"squad": replacement.Id}, "$set": bson.M{"updated_at": now}}
+		_, err = a.MongoDBClient.Collection("teams").UpdateOne(sessCtx, teamFilter, teamUpdate)
+		return nil, err
+	})
+	if err != nil {
+		if errors.Is(err, errPlayerReplaced) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Player " + player.PlayerName + " was already replaced"})
+			return
+		}
+		a.logger.Error("failed to register replacement", zap.Error(err), zap.Any("player_id", player.Id))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register replacement"})
+		return
+	}
+
+	if err = a.clearPlayerCache(ctx, player.AuctionId); err != nil {
+		a.logger.Warn("failed to clear player cache after replacement", zap.Error(err))
+	}
+	if err = a.clearLeaderboardCache(ctx, player.AuctionId); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after replacement", zap.Error(err))
+	}
+	if _, err = a.RedisClient.Del(ctx, fmt.Sprintf(TeamCacheKey, player.AuctionId)).Result(); err != nil {
+		a.logger.Warn("failed to clear team cache after replacement", zap.Error(err))
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "Replacement registered successfully",
+		"replacement": replacement,
+	})
+}
+
+// findAvailabilityPlayer loads the player and checks its auction allows availability
+// changes, responding with an error otherwise
+func (a *API) findAvailabilityPlayer(ctx context.Context, c *gin.Context, playerID primitive.ObjectID, player *models.Player) bool {
+	err := a.MongoDBClient.Collection("players").FindOne(ctx, bson.M{"_id": playerID}).Decode(player)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return false
+		}
+		a.logger.Error("failed to find player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return false
+	}
+
+	if err = utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpAvailability, player.AuctionId); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", player.AuctionId))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return false
+	}
+	return true
+}
+
+// availabilityWarnings lists the picked players who are not available to play
+func availabilityWarnings(squad []models.Player, picked map[primitive.ObjectID]bool) []string {
+	warnings := []string{}
+	for _, player := range squad {
+		if !picked[player.Id] || player.Availability == nil {
+			continue
+		}
+		warning := fmt.Sprintf("%s is %s", player.PlayerName, player.Availability.Status)
+		if player.Availability.Note != "" {
+			warning += ": " + player.Availability.Note
+		}
+		if player.Availability.ExpectedReturn != nil {
+			warning += fmt.Sprintf(" (expected back %s)", player.Availability.ExpectedReturn.Format(time.DateOnly))
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
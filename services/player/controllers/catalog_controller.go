@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GetCatalogPlayersController lists the catalog, optionally searched by name, role and IPL team
+func (a *API) GetCatalogPlayersController(c *gin.Context) {
+	var request struct {
+		Search  string `json:"search"`
+		Role    string `json:"role"`
+		IPLTeam string `json:"ipl_team"`
+	}
+	var catalog []models.CatalogPlayer
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get catalog request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	filter := bson.M{}
+	if request.Search != "" {
+		filter["player_name"] = primitive.Regex{Pattern: regexp.QuoteMeta(request.Search), Options: "i"}
+	}
+	if request.Role != "" {
+		filter["role"] = request.Role
+	}
+	if request.IPLTeam != "" {
+		filter["ipl_team"] = request.IPLTeam
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "player_name", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("player_catalog").Find(ctx, filter, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch catalog players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &catalog); err != nil {
+		a.logger.Error("failed to decode catalog players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Catalog fetched successfully",
+		"catalog": catalog,
+	})
+}
+
+// CreateCatalogPlayerController adds a new player identity to the catalog
+func (a *API) CreateCatalogPlayerController(c *gin.Context) {
+	var request models.CatalogPlayer
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind create catalog player request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if !models.ValidPlayerRole(request.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player role"})
+		return
+	}
+
+	request.ID = primitive.NewObjectID()
+	request.CreatedAt = time.Now()
+	request.UpdatedAt = time.Now()
+
+	if _, err := a.MongoDBClient.Collection("player_catalog").InsertOne(ctx, request); err != nil {
+		a.logger.Error("failed to create catalog player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Catalog player created successfully",
+		"player":  request,
+	})
+}
+
+// UpdateCatalogPlayerController fixes the provided fields of a catalog entry and of the
+// auction players linked to it. Completed and archived auctions keep the role and IPL
+// team they were played with, only name and country corrections reach them.
+func (a *API) UpdateCatalogPlayerController(c *gin.Context) {
+	var (
+		request struct {
+			ID         primitive.ObjectID `json:"id" binding:"required"`
+			PlayerName *string            `json:"player_name"`
+			Country    *string            `json:"country"`
+			Role       *string            `json:"role"`
+			IPLTeam    *string            `json:"ipl_team"`
+			PhotoURL   *string            `json:"photo_url"`
+		}
+		response models.CatalogPlayer
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind update catalog player request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.PlayerName != nil && *request.PlayerName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player name cannot be empty"})
+		return
+	}
+	if request.Role != nil && !models.ValidPlayerRole(*request.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player role"})
+		return
+	}
+
+	// Identity fields hold for every auction, season fields only for the running ones
+	identity, season := bson.M{}, bson.M{}
+	if request.PlayerName != nil {
+		identity["player_name"] = *request.PlayerName
+	}
+	if request.Country != nil {
+		identity["country"] = *request.Country
+	}
+	if request.Role != nil {
+		season["role"] = *request.Role
+	}
+	if request.IPLTeam != nil {
+		season["ipl_team"] = *request.IPLTeam
+	}
+
+	catalogSet := bson.M{"updated_at": time.Now()}
+	for _, fields := range []bson.M{identity, season} {
+		for key, value := range fields {
+			catalogSet[key] = value
+		}
+	}
+	if request.PhotoURL != nil {
+		catalogSet["photo_url"] = *request.PhotoURL
+	}
+	if len(catalogSet) == 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		return
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := a.MongoDBClient.Collection("player_catalog").FindOneAndUpdate(ctx, bson.M{"_id": request.ID}, bson.M{"$set": catalogSet}, opts).Decode(&response)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Catalog player not found"})
+			return
+		}
+		a.logger.Error("failed to update catalog player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update catalog player"})
+		return
+	}
+
+	linkedAuctions, err := a.propagateCatalogUpdate(ctx, request.ID, identity, season)
+	if err != nil {
+		a.logger.Error("failed to propagate catalog update to players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update auction players"})
+		return
+	}
+	if err = a.clearPlayerCache(ctx, linkedAuctions...); err != nil {
+		a.logger.Warn("failed to clear player cache after catalog update", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Catalog player updated successfully",
+		"player":  response,
+	})
+}
+
+// propagateCatalogUpdate sets the identity fields on every auction player linked to the
+// catalog entry and the season fields on those of auctions still running, returning
+// the auctions whose players changed
+func (a *API) propagateCatalogUpdate(ctx context.Context, catalogID primitive.ObjectID, identity, season bson.M) ([]primitive.ObjectID, error) {
+	values, err := a.MongoDBClient.Collection("players").Distinct(ctx, "auction_id", bson.M{"catalog_id": catalogID})
+	if err != nil {
+		return nil, err
+	}
+	linkedAuctions := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			linkedAuctions = append(linkedAuctions, id)
+		}
+	}
+	if len(linkedAuctions) == 0 {
+		return nil, nil
+	}
+
+	if len(identity) > 0 {
+		identity["updated_at"] = time.Now()
+		filter := bson.M{"catalog_id": catalogID}
+		if _, err = a.MongoDBClient.Collection("players").UpdateMany(ctx, filter, bson.M{"$set": identity}); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(season) > 0 {
+		running, err := a.MongoDBClient.Collection("auctions").Distinct(ctx, "_id", bson.M{
+			"_id":    bson.M{"$in": linkedAuctions},
+			"status": bson.M{"$nin": []string{models.AuctionStatusCompleted, models.AuctionStatusArchived}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(running) > 0 {
+			season["updated_at"] = time.Now()
+			filter := bson.M{"catalog_id": catalogID, "auction_id": bson.M{"$in": running}}
+			if _, err = a.MongoDBClient.Collection("players").UpdateMany(ctx, filter, bson.M{"$set": season}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return linkedAuctions, nil
+}
+
+// CatalogHistoryController returns every auction appearance of a catalog player
+func (a *API) CatalogHistoryController(c *gin.Context) {
+	var request struct {
+		CatalogID primitive.ObjectID `json:"catalog_id" binding:"required"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind catalog history request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if email == "" {
+		a.logger.Error("failed to fetch email from token")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Email not found in token"})
+		return
+	}
+
+	history, err := a.priceHistory(ctx, request.CatalogID, primitive.NilObjectID)
+	if err != nil {
+		a.logger.Error("failed to fetch catalog history", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	// Private auctions of which the caller is not a member are only listed by price and date
+	history, err = a.redactPriceHistory(ctx, history, email)
+	if err != nil {
+		a.logger.Error("failed to check catalog history visibility", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Catalog history fetched successfully",
+		"history": history,
+	})
+}
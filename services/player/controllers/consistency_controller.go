@@ -0,0 +1,379 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ConsistencyReport lists the broken references between players, match documents
+// and squads, and whether they were repaired
+type ConsistencyReport struct {
+	AuctionID         *primitive.ObjectID  `json:"auction_id,omitempty"` // nil when every auction was checked
+	OrphanedMatches   []primitive.ObjectID `json:"orphaned_matches"`
+	MissingMatches    []MissingMatch       `json:"missing_matches"`
+	DanglingSquads    []DanglingSquad      `json:"dangling_squads"`
+	Repaired          bool                 `json:"repaired"`
+	BackfilledMatches int64                `json:"backfilled_matches"`
+}
+
+// MissingMatch is a player of an IPL auction without a match document, Match is set
+// when the player references a document that does not exist
+type MissingMatch struct {
+	PlayerID   primitive.ObjectID  `bson:"_id" json:"player_id"`
+	AuctionID  primitive.ObjectID  `bson:"auction_id" json:"auction_id"`
+	PlayerName string              `bson:"player_name" json:"player_name"`
+	Match      *primitive.ObjectID `bson:"match,omitempty" json:"match,omitempty"`
+}
+
+// DanglingSquad is a team whose squad references players missing from its auction
+type DanglingSquad struct {
+	TeamID    primitive.ObjectID   `json:"team_id"`
+	AuctionID primitive.ObjectID   `json:"auction_id"`
+	TeamName  string               `json:"team_name"`
+	PlayerIDs []primitive.ObjectID `json:"player_ids"`
+}
+
+// Clean reports whether no inconsistency was found
+func (r ConsistencyReport) Clean() bool {
+	return len(r.OrphanedMatches) == 0 && len(r.MissingMatches) == 0 && len(r.DanglingSquads) == 0
+}
+
+// ConsistencyController checks the match documents and squads of an auction and
+// repairs them on request
+func (a *API) ConsistencyController(c *gin.Context) {
+	var request struct {
+		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		Repair    bool               `json:"repair"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind consistency request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.Repair {
+		if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpUpdate, request.AuctionID); err != nil {
+			a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+			status, message := utils.AuctionGuardResponse(err)
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
+	}
+
+	report, err := a.CheckConsistency(ctx, request.AuctionID, request.Repair)
+	if err != nil {
+		a.logger.Error("failed to check consistency", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check consistency"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Consistency checked successfully",
+		"clean":   report.Clean(),
+		"report":  report,
+	})
+}
+
+// CheckConsistency finds the match documents no player references, the players of
+// IPL auctions without a match document and the squad entries pointing at players
+// missing from the auction of the team. A zero auction ID checks every auction,
+// which is the only way to find orphaned match documents created before they
+// recorded their auction. With repair set orphaned match documents are deleted,
+// missing ones created and dangling squad entries pulled, and the match documents
+// of the players get their auction recorded.
+func (a *API) CheckConsistency(ctx context.Context, auctionID primitive.ObjectID, repair bool) (ConsistencyReport, error) {
+	var (
+		report ConsistencyReport
+		err    error
+	)
+
+	if !auctionID.IsZero() {
+		report.AuctionID = &auctionID
+	}
+	if report.OrphanedMatches, err = a.findOrphanedMatches(ctx, auctionID); err != nil {
+		return report, err
+	}
+	if report.MissingMatches, err = a.findMissingMatches(ctx, auctionID); err != nil {
+		return report, err
+	}
+	if report.DanglingSquads, err = a.findDanglingSquads(ctx, auctionID); err != nil {
+		return report, err
+	}
+
+	if !repair {
+		return report, nil
+	}
+	if !report.Clean() {
+		if err = a.repairConsistency(ctx, report); err != nil {
+			return report, err
+		}
+		report.Repaired = true
+	}
+	if report.BackfilledMatches, err = a.backfillMatchAuctions(ctx, auctionID); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// backfillMatchAuctions records the auction on the match documents of the players
+// that were created before match documents had one
+func (a *API) backfillMatchAuctions(ctx context.Context, auctionID primitive.ObjectID) (int64, error) {
+	var groups []struct {
+		AuctionID primitive.ObjectID   `bson:"_id"`
+		Matches   []primitive.ObjectID `bson:"matches"`
+	}
+
+	filter := bson.M{"match": bson.M{"$exists": true, "$ne": primitive.NilObjectID}}
+	if !auctionID.IsZero() {
+		filter["auction_id"] = auctionID
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$auction_id", "matches": bson.M{"$push": "$match"}}}},
+	}
+	cursor, err := a.MongoDBClient.Collection("players").Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return 0, err
+	}
+
+	var backfilled int64
+	for _, group := range groups {
+		result, err := a.MongoDBClient.Collection("matches").UpdateMany(ctx,
+			bson.M{"_id": bson.M{"$in": group.Matches}, "auction_id": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"auction_id": group.AuctionID}})
+		if err != nil {
+			return backfilled, err
+		}
+		backfilled += result.ModifiedCount
+	}
+	return backfilled, nil
+}
+
+// findOrphanedMatches returns the match documents no player references
+func (a *API) findOrphanedMatches(ctx context.Context, auctionID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var orphans []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	pipeline := mongo.Pipeline{}
+	if !auctionID.IsZero() {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"auction_id": auctionID}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "players",
+			"localField":   "_id",
+			"foreignField": "match",
+			"as":           "players",
+		}}},
+		bson.D{{Key: "$match", Value: bson.M{"players": bson.M{"$size": 0}}}},
+		bson.D{{Key: "$project", Value: bson.M{"_id": 1}}},
+	)
+
+	cursor, err := a.MongoDBClient.Collection("matches").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &orphans); err != nil {
+		return nil, err
+	}
+
+	matchIDs := make([]primitive.ObjectID, 0, len(orphans))
+	for _, orphan := range orphans {
+		matchIDs = append(matchIDs, orphan.ID)
+	}
+	return matchIDs, nil
+}
+
+// findMissingMatches returns the players of IPL auctions whose match document is
+// unset or does not exist
+func (a *API) findMissingMatches(ctx context.Context, auctionID primitive.ObjectID) ([]MissingMatch, error) {
+	missing := []MissingMatch{}
+
+	auctionFilter := bson.M{"is_ipl_auction": true}
+	if !auctionID.IsZero() {
+		auctionFilter["_id"] = auctionID
+	}
+	auctionIDs, err := a.MongoDBClient.Collection("auctions").Distinct(ctx, "_id", auctionFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(auctionIDs) == 0 {
+		return missing, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"auction_id": bson.M{"$in": auctionIDs}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "matches",
+			"localField":   "match",
+			"foreignField": "_id",
+			"as":           "match_docs",
+		}}},
+		{{Key: "$match", Value: bson.M{"match_docs": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"auction_id": 1, "player_name": 1, "match": 1}}},
+	}
+	cursor, err := a.MongoDBClient.Collection("players").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &missing); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// findDanglingSquads returns the teams whose squad references players that do not
+// exist in the auction of the team
+func (a *API) findDanglingSquads(ctx context.Context, auctionID primitive.ObjectID) ([]DanglingSquad, error) {
+	var teams []struct {
+		ID        primitive.ObjectID   `bson:"_id"`
+		AuctionID primitive.ObjectID   `bson:"auction_id"`
+		TeamName  string               `bson:"team_name"`
+		Squad     []primitive.ObjectID `bson:"squad"`
+		Players   []struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			AuctionID primitive.ObjectID `bson:"auction_id"`
+		} `bson:"players"`
+	}
+	dangling := []DanglingSquad{}
+
+	filter := bson.M{"squad.0": bson.M{"$exists": true}}
+	if !auctionID.IsZero() {
+		filter["auction_id"] = auctionID
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "players",
+			"localField":   "squad",
+			"foreignField": "_id",
+			"as":           "players",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"auction_id":         1,
+			"team_name":          1,
+			"squad":              1,
+			"players._id":        1,
+			"players.auction_id": 1,
+		}}},
+	}
+	cursor, err := a.MongoDBClient.Collection("teams").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &teams); err != nil {
+		return nil, err
+	}
+
+	for _, team := range teams {
+		found := make(map[primitive.ObjectID]bool, len(team.Players))
+		for _, player := range team.Players {
+			if player.AuctionID == team.AuctionID {
+				found[player.ID] = true
+			}
+		}
+
+		var playerIDs []primitive.ObjectID
+		for _, playerID := range team.Squad {
+			if !found[playerID] {
+				playerIDs = append(playerIDs, playerID)
+			}
+		}
+		if len(playerIDs) > 0 {
+			dangling = append(dangling, DanglingSquad{
+				TeamID:    team.ID,
+				AuctionID: team.AuctionID,
+				TeamName:  team.TeamName,
+				PlayerIDs: playerIDs,
+			})
+		}
+	}
+	return dangling, nil
+}
+
+// repairConsistency fixes every inconsistency of the report in one transaction and
+// clears the caches of the auctions it touched
+func (a *API) repairConsistency(ctx context.Context, report ConsistencyReport) error {
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	touched := make(map[primitive.ObjectID]bool)
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		matches := a.MongoDBClient.Collection("matches")
+
+		if len(report.OrphanedMatches) > 0 {
+			if _, err := matches.DeleteMany(sessCtx, bson.M{"_id": bson.M{"$in": report.OrphanedMatches}}); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, missing := range report.MissingMatches {
+			match := models.Match{
+				Id:        primitive.NewObjectID(),
+				AuctionID: missing.AuctionID,
+				Matches:   []int{},
+			}
+			if _, err := matches.InsertOne(sessCtx, match); err != nil {
+				return nil, err
+			}
+			if _, err := a.MongoDBClient.Collection("players").UpdateByID(sessCtx, missing.PlayerID, bson.M{"$set": bson.M{"match": match.Id}}); err != nil {
+				return nil, err
+			}
+			touched[missing.AuctionID] = true
+		}
+
+		for _, squad := range report.DanglingSquads {
+			update := bson.M{"$pull": bson.M{"squad": bson.M{"$in": squad.PlayerIDs}}}
+			if _, err := a.MongoDBClient.Collection("teams").UpdateByID(sessCtx, squad.TeamID, update); err != nil {
+				return nil, err
+			}
+			touched[squad.AuctionID] = true
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	auctionIDs := make([]primitive.ObjectID, 0, len(touched))
+	teamKeys := make([]string, 0, len(touched))
+	for auctionID := range touched {
+		auctionIDs = append(auctionIDs, auctionID)
+		teamKeys = append(teamKeys, fmt.Sprintf(TeamCacheKey, auctionID))
+	}
+	if len(auctionIDs) == 0 {
+		return nil
+	}
+
+	if err = a.clearPlayerCache(ctx, auctionIDs...); err != nil {
+		a.logger.Warn("failed to clear player cache after repair", zap.Error(err))
+	}
+	if err = a.clearLeaderboardCache(ctx, auctionIDs...); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after repair", zap.Error(err))
+	}
+	if _, err = a.RedisClient.Del(ctx, teamKeys...).Result(); err != nil {
+		a.logger.Warn("failed to clear team cache after repair", zap.Error(err))
+	}
+	return nil
+}
@@ -3,7 +3,26 @@ package controllers
 import "time"
 
 var (
-	TTLTime        = 1 * time.Hour
-	PlayerCacheKey = "players:auction:%s"
-	PlayerTTL      = 5 * time.Minute
+	TTLTime             = 1 * time.Hour
+	PlayerCacheKey      = "players:auction:%s"
+	PlayerTTL           = 5 * time.Minute
+	IdempotencyHeader   = "Idempotency-Key"
+	IdempotencyKeyTTL   = 24 * time.Hour
+	MaxPlayerPageSize   = int64(200)
+	HomeCountry         = "India"
+	UpcomingLots        = int64(10)
+	MinPointsFactor     = 0.75
+	MaxPointsFactor     = 1.25
+	PlayingXISize       = 11
+	MaxOverseasInXI     = 4
+	RolloverInterval    = 1 * time.Minute
+	LeaderboardCacheKey = "leaderboard:auction:%s"
+	LeaderboardTTL      = 5 * time.Minute
+	WaiverInterval      = 1 * time.Minute
+	TeamCacheKey        = "team_list_%s"
+	MaxH2HLegs          = 2
+	H2HWinPoints        = 3
+	H2HDrawPoints       = 1
+	ConsistencyTimeout  = 5 * time.Minute
+	ScanBatchSize       = int64(100)
 )
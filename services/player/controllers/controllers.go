@@ -4,6 +4,8 @@ import (
 	"auction-web/internal/config"
 	"auction-web/internal/database"
 	"auction-web/internal/logger"
+	"auction-web/pkg/middlewares"
+	"auction-web/pkg/models"
 	"context"
 
 	"github.com/gin-gonic/gin"
@@ -33,11 +35,15 @@ func NewAPI(ctx context.Context) (*API, error) {
 
 	redisClient := database.NewRedisClient(redisCfg.RedisURI, redisCfg.RedisPassword)
 
-	return &API{
+	api := &API{
 		logger:        auctionLogger,
 		MongoDBClient: db,
 		RedisClient:   redisClient,
-	}, nil
+	}
+	if err = api.ensureIdempotencyIndex(ctx); err != nil {
+		return nil, logger.WrapError(err, "failed to create idempotency key index")
+	}
+	return api, nil
 }
 
 // RegisterRoutes register all the handlers to gin router
@@ -51,13 +57,120 @@ func (a *API) RegisterRoutes(router *gin.Engine) {
 
 	playersGroup := router.Group("/api/v1/players")
 
-	playersGroup.POST("/get", a.GetAllPlayersController)
+	// Per auction authorization of the player routes
+	admins := middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromBody, models.AuctionAdminRoles...)
+	members := middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromBody, models.AuctionMemberRoles...)
+
+	playersGroup.POST("/get", members, a.GetAllPlayersController)
+
+	playersGroup.POST("/save", admins, a.SavePlayerController)
+
+	playersGroup.POST("/import", middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromForm, models.AuctionAdminRoles...), a.ImportPlayersController)
+
+	playersGroup.PATCH("/update", admins, a.UpdatePlayerController)
+
+	playersGroup.DELETE("/delete", middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromPlayers, models.AuctionAdminRoles...), a.DeletePlayerController)
+
+	playersGroup.POST("/detail", middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromPlayers, models.AuctionMemberRoles...), a.PlayerDetailController)
+
+	playersGroup.POST("/squad", middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromPlayers, models.AuctionMemberRoles...), a.SquadsController)
+
+	playersGroup.POST("/populate", admins, a.PopulateAuctionController)
+
+	playersGroup.POST("/consistency", admins, a.ConsistencyController)
+
+	availabilityGroup := playersGroup.Group("/availability", middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromPlayers, models.AuctionAdminRoles...))
+
+	availabilityGroup.PUT("", a.SetAvailabilityController)
+
+	availabilityGroup.POST("/replacement", a.RegisterReplacementController)
+
+	scorecardGroup := playersGroup.Group("/scorecards")
+
+	scorecardGroup.POST("", admins, a.IngestScorecardController)
+
+	scorecardGroup.POST("/get", members, a.GetScorecardsController)
+
+	scoringGroup := playersGroup.Group("/scoring")
+
+	scoringGroup.POST("/get", members, a.GetScoringRulesController)
+
+	scoringGroup.PUT("", admins, a.SaveScoringRulesController)
+
+	scoringGroup.POST("/recalculate", admins, a.RecalculatePointsController)
+
+	scoringGroup.POST("/preview", a.PreviewScoringController)
+
+	lineupGroup := playersGroup.Group("/lineup")
+
+	lineupGroup.POST("/get", members, a.GetLineupController)
+
+	lineupGroup.PUT("", members, a.SaveLineupController)
+
+	lineupGroup.PUT("/deadline", admins, a.SetLineupDeadlineController)
+
+	playersGroup.POST("/leaderboard", members, a.LeaderboardController)
+
+	fixturesGroup := playersGroup.Group("/fixtures")
+
+	fixturesGroup.POST("/import", middlewares.AuthorizeAuction(a.MongoDBClient, middlewares.AuctionFromForm, models.AuctionAdminRoles...), a.ImportFixturesController)
+
+	fixturesGroup.POST("/get", members, a.GetFixturesController)
+
+	fixturesGroup.POST("/players", members, a.FixturePlayersController)
+
+	matchdayGroup := playersGroup.Group("/matchday")
+
+	matchdayGroup.POST("/rollover", admins, a.RolloverMatchdayController)
+
+	matchdayGroup.POST("/history", members, a.MatchdayHistoryController)
+
+	// Watchlists and notes are checked against the team owners in the handlers
+	watchlistGroup := playersGroup.Group("/watchlist", members)
+
+	watchlistGroup.POST("/get", a.GetWatchlistController)
+
+	watchlistGroup.PUT("", a.SaveWatchlistEntryController)
+
+	watchlistGroup.DELETE("", a.RemoveWatchlistEntryController)
+
+	watchlistGroup.POST("/upcoming", a.UpcomingWatchlistController)
+
+	notesGroup := playersGroup.Group("/notes", members)
+
+	notesGroup.POST("/get", a.GetPlayerNotesController)
+
+	notesGroup.PUT("", a.SavePlayerNoteController)
+
+	h2hGroup := playersGroup.Group("/h2h")
+
+	h2hGroup.PUT("/schedule", admins, a.CreateH2HScheduleController)
+
+	h2hGroup.POST("/get", members, a.GetH2HLeagueController)
+
+	waiverGroup := playersGroup.Group("/waivers")
+
+	waiverGroup.PUT("/settings", admins, a.SaveWaiverSettingsController)
+
+	waiverGroup.POST("/process", admins, a.ProcessWaiversController)
+
+	// Claims are blind, they are checked against the team owners in the handlers
+	waiverGroup.POST("/get", members, a.GetWaiverClaimsController)
+
+	waiverGroup.PUT("/claim", members, a.SubmitWaiverClaimController)
+
+	waiverGroup.DELETE("/claim", members, a.CancelWaiverClaimController)
+
+	// The catalog is shared by every auction, only platform admins can change it
+	platformAdmins := middlewares.AuthorizeAdmin(config.LoadAdminConfig().Emails)
+
+	catalogGroup := playersGroup.Group("/catalog")
 
-	playersGroup.POST("/save", a.SavePlayerController)
+	catalogGroup.POST("/get", a.GetCatalogPlayersController)
 
-	playersGroup.PATCH("/update", a.UpdatePlayerController)
+	catalogGroup.POST("", platformAdmins, a.CreateCatalogPlayerController)
 
-	playersGroup.DELETE("/delete", a.DeletePlayerController)
+	catalogGroup.PATCH("", platformAdmins, a.UpdateCatalogPlayerController)
 
-	playersGroup.POST("/squad", a.SquadsController)
+	catalogGroup.POST("/history", a.CatalogHistoryController)
 }
@@ -3,8 +3,8 @@ package controllers
 import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -41,6 +41,13 @@ func (a *API) DeletePlayerController(c *gin.Context) {
 		return
 	}
 
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManagePlayers, player.AuctionId); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", player.AuctionId))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
 	// Delete the corresponding match document
 	if !player.Match.IsZero() {
 		matchFilter := bson.M{"_id": player.Match}
@@ -58,13 +65,25 @@ func (a *API) DeletePlayerController(c *gin.Context) {
 		return
 	}
 
+	// Drop the player from every watchlist and note of the auction
+	scoutingFilter := bson.M{"player_id": request.PlayerID}
+	if _, err = a.MongoDBClient.Collection("watchlists").DeleteMany(ctx, scoutingFilter); err != nil {
+		a.logger.Warn("failed to delete watchlist entries of player", zap.Error(err))
+	}
+	if _, err = a.MongoDBClient.Collection("player_notes").DeleteMany(ctx, scoutingFilter); err != nil {
+		a.logger.Warn("failed to delete notes of player", zap.Error(err))
+	}
+	waiverFilter := bson.M{"player_id": request.PlayerID, "status": models.WaiverClaimPending}
+	if _, err = a.MongoDBClient.Collection("waiver_claims").DeleteMany(ctx, waiverFilter); err != nil {
+		a.logger.Warn("failed to delete waiver claims of player", zap.Error(err))
+	}
+
 	// Clear relevant cache entries
-	cachePattern := fmt.Sprintf("players:auction:%s:*", player.AuctionId.Hex())
-	keys, err := a.RedisClient.Keys(ctx, cachePattern).Result()
-	if err == nil && len(keys) > 0 {
-		if _, err = a.RedisClient.Del(ctx, keys...).Result(); err != nil {
-			a.logger.Warn("failed to clear player cache after deletion", zap.Error(err))
-		}
+	if err = a.clearPlayerCache(ctx, player.AuctionId); err != nil {
+		a.logger.Warn("failed to clear player cache after deletion", zap.Error(err))
+	}
+	if err = a.clearLeaderboardCache(ctx, player.AuctionId); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after deletion", zap.Error(err))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
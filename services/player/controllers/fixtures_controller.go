@@ -0,0 +1,436 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// fixtureInput is one match of an imported schedule, from a JSON object or a file row
+type fixtureInput struct {
+	MatchNumber int    `json:"match_number"`
+	HomeTeam    string `json:"home_team"`
+	AwayTeam    string `json:"away_team"`
+	Venue       string `json:"venue"`
+	StartTime   string `json:"start_time"`
+	Timezone    string `json:"timezone"`
+}
+
+// fixturePlayers is a fixture along with the auction players of both its IPL teams
+type fixturePlayers struct {
+	models.Fixture
+	Players []models.Player `json:"players"`
+}
+
+// ImportFixturesController replaces the fixtures of an auction with a JSON, CSV or
+// XLSX schedule and derives the matchdays and the next XI deadline from it
+func (a *API) ImportFixturesController(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	auctionID, err := primitive.ObjectIDFromHex(c.PostForm("auction_id"))
+	if err != nil {
+		a.logger.Error("failed to parse auction id of fixtures import", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid auction id"})
+		return
+	}
+
+	if err = utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpUpdate, auctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", auctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		a.logger.Error("failed to read fixtures file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		a.logger.Error("failed to open fixtures file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to open file"})
+		return
+	}
+	defer file.Close()
+
+	inputs, err := readFixtureInputs(file, fileHeader.Filename)
+	if err != nil {
+		a.logger.Error("failed to parse fixtures file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(inputs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File has no fixtures"})
+		return
+	}
+
+	fixtures, report := buildFixtures(inputs, auctionID)
+	if dryRun || len(report) > 0 {
+		status := http.StatusOK
+		if len(report) > 0 {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"message":  "Fixtures validated",
+			"dry_run":  dryRun,
+			"fixtures": fixtures,
+			"errors":   report,
+		})
+		return
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		docs := make([]any, 0, len(fixtures))
+		for _, fixture := range fixtures {
+			docs = append(docs, fixture)
+		}
+
+		if _, err := a.MongoDBClient.Collection("fixtures").DeleteMany(sessCtx, bson.M{"auction_id": auctionID}); err != nil {
+			return nil, err
+		}
+		if _, err := a.MongoDBClient.Collection("fixtures").InsertMany(sessCtx, docs); err != nil {
+			return nil, err
+		}
+		return nil, a.syncLineupDeadline(sessCtx, auctionID)
+	})
+	if err != nil {
+		a.logger.Error("failed to import fixtures", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import fixtures"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Fixtures imported successfully",
+		"fixtures":  len(fixtures),
+		"matchdays": fixtures[len(fixtures)-1].Matchday,
+	})
+}
+
+// GetFixturesController returns the fixtures of an auction, optionally of one matchday
+func (a *API) GetFixturesController(c *gin.Context) {
+	var request struct {
+		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		Matchday  int                `json:"matchday"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get fixtures request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	fixtures, err := a.findFixtures(ctx, request.AuctionID, request.Matchday)
+	if err != nil {
+		a.logger.Error("failed to fetch fixtures", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Fixtures fetched successfully",
+		"fixtures": fixtures,
+	})
+}
+
+// FixturePlayersController maps the players of an auction to the fixtures of a
+// matchday through their IPL team, the next matchday when none is given
+func (a *API) FixturePlayersController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Matchday  int                `json:"matchday"`
+		}
+		players []models.Player
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind fixture players request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.Matchday <= 0 {
+		season, err := a.rolloverDueMatchday(ctx, request.AuctionID)
+		if err != nil {
+			a.logger.Error("failed to roll over matchday", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+		request.Matchday = season.Matchday + 1
+	}
+
+	fixtures, err := a.findFixtures(ctx, request.AuctionID, request.Matchday)
+	if err != nil {
+		a.logger.Error("failed to fetch fixtures", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	filter := bson.M{"auction_id": request.AuctionID, "ipl_team": bson.M{"$nin": bson.A{"", nil}}}
+	findOptions := options.Find().SetSort(bson.D{{Key: "player_number", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, filter, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		a.logger.Error("failed to decode players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	response := make([]fixturePlayers, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		entry := fixturePlayers{Fixture: fixture, Players: []models.Player{}}
+		for _, player := range players {
+			if fixture.Involves(player.IPLTeam) {
+				entry.Players = append(entry.Players, player)
+			}
+		}
+		response = append(response, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Fixture players fetched successfully",
+		"matchday": request.Matchday,
+		"fixtures": response,
+	})
+}
+
+// readFixtureInputs reads the schedule from a JSON array or a CSV/XLSX file with
+// the columns match_number, home_team, away_team, venue, start_time and timezone
+func readFixtureInputs(file io.Reader, filename string) ([]fixtureInput, error) {
+	var inputs []fixtureInput
+
+	if strings.ToLower(filepath.Ext(filename)) == ".json" {
+		if err := json.NewDecoder(file).Decode(&inputs); err != nil {
+			return nil, errors.New("invalid JSON schedule, expected an array of fixtures")
+		}
+		return inputs, nil
+	}
+
+	rows, err := readImportRows(file, filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+
+	for _, row := range rows[1:] {
+		cell := func(column string) string {
+			idx, ok := columns[column]
+			if !ok || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+
+		// An unparsable number is left at zero and reported by buildFixtures
+		matchNumber, _ := strconv.Atoi(cell("match_number"))
+		inputs = append(inputs, fixtureInput{
+			MatchNumber: matchNumber,
+			HomeTeam:    cell("home_team"),
+			AwayTeam:    cell("away_team"),
+			Venue:       cell("venue"),
+			StartTime:   cell("start_time"),
+			Timezone:    cell("timezone"),
+		})
+	}
+	return inputs, nil
+}
+
+// buildFixtures validates the schedule and numbers its matchdays: every calendar
+// day with a match, in the display timezone of the match, is one matchday
+func buildFixtures(inputs []fixtureInput, auctionID primitive.ObjectID) ([]models.Fixture, []importRowError) {
+	var (
+		now      = time.Now()
+		fixtures = make([]models.Fixture, 0, len(inputs))
+		report   = []importRowError{}
+		seen     = make(map[int]int)
+	)
+
+	for i, input := range inputs {
+		row := i + 1
+		var rowErrors []string
+
+		fixture := models.Fixture{
+			ID:          primitive.NewObjectID(),
+			AuctionID:   auctionID,
+			MatchNumber: input.MatchNumber,
+			HomeTeam:    strings.TrimSpace(input.HomeTeam),
+			AwayTeam:    strings.TrimSpace(input.AwayTeam),
+			Venue:       strings.TrimSpace(input.Venue),
+			Timezone:    strings.TrimSpace(input.Timezone),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		if fixture.MatchNumber <= 0 {
+			rowErrors = append(rowErrors, "match_number must be a positive integer")
+		} else if prev, ok := seen[fixture.MatchNumber]; ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("match_number %d duplicates row %d", fixture.MatchNumber, prev))
+		}
+
+		if fixture.HomeTeam == "" || fixture.AwayTeam == "" {
+			rowErrors = append(rowErrors, "home_team and away_team are required")
+		} else if strings.EqualFold(fixture.HomeTeam, fixture.AwayTeam) {
+			rowErrors = append(rowErrors, "home_team and away_team must differ")
+		}
+
+		if fixture.Timezone == "" {
+			fixture.Timezone = models.DefaultFixtureTimezone
+		}
+		location, err := time.LoadLocation(fixture.Timezone)
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("timezone %q is invalid", fixture.Timezone))
+		} else if startTime, err := parseFixtureTime(input.StartTime, location); err != nil {
+			rowErrors = append(rowErrors, "start_time must be RFC 3339 or \"YYYY-MM-DD HH:MM\" in the timezone")
+		} else {
+			fixture.StartTime = startTime
+		}
+
+		if len(rowErrors) > 0 {
+			report = append(report, importRowError{Row: row, Errors: rowErrors})
+			continue
+		}
+		seen[fixture.MatchNumber] = row
+		fixtures = append(fixtures, fixture)
+	}
+
+	sort.SliceStable(fixtures, func(i, j int) bool {
+		if !fixtures[i].StartTime.Equal(fixtures[j].StartTime) {
+			return fixtures[i].StartTime.Before(fixtures[j].StartTime)
+		}
+		return fixtures[i].MatchNumber < fixtures[j].MatchNumber
+	})
+
+	matchday, lastDay := 0, ""
+	for i := range fixtures {
+		location, _ := time.LoadLocation(fixtures[i].Timezone)
+		day := fixtures[i].StartTime.In(location).Format(time.DateOnly)
+		if day != lastDay {
+			matchday++
+			lastDay = day
+		}
+		fixtures[i].Matchday = matchday
+	}
+
+	return fixtures, report
+}
+
+// parseFixtureTime parses a start time with an offset, or a wall clock time in the
+// location, and returns it in UTC
+func parseFixtureTime(value string, location *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04", value, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// findFixtures returns the fixtures of the auction in start order, only those of the
+// matchday when it is set
+func (a *API) findFixtures(ctx context.Context, auctionID primitive.ObjectID, matchday int) ([]models.Fixture, error) {
+	fixtures := []models.Fixture{}
+
+	filter := bson.M{"auction_id": auctionID}
+	if matchday > 0 {
+		filter["matchday"] = matchday
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}, {Key: "match_number", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("fixtures").Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &fixtures); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+// fixtureLockAt returns the XI deadline of a matchday, the start of its first
+// fixture, nil when the matchday has no fixtures
+func (a *API) fixtureLockAt(ctx context.Context, auctionID primitive.ObjectID, matchday int) (*time.Time, error) {
+	var fixture models.Fixture
+
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "start_time", Value: 1}})
+	err := a.MongoDBClient.Collection("fixtures").FindOne(ctx, bson.M{"auction_id": auctionID, "matchday": matchday}, findOptions).Decode(&fixture)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &fixture.StartTime, nil
+}
+
+// syncLineupDeadline sets the XI deadline of the next matchday from the fixtures
+func (a *API) syncLineupDeadline(ctx context.Context, auctionID primitive.ObjectID) error {
+	var season models.FantasySeason
+
+	err := a.MongoDBClient.Collection("fantasy_seasons").FindOne(ctx, bson.M{"_id": auctionID}).Decode(&season)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	lockAt, err := a.fixtureLockAt(ctx, auctionID, season.Matchday+1)
+	if err != nil || lockAt == nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set":         bson.M{"lock_at": *lockAt, "updated_at": time.Now()},
+		"$setOnInsert": bson.M{"matchday": 0},
+	}
+	_, err = a.MongoDBClient.Collection("fantasy_seasons").UpdateOne(ctx, bson.M{"_id": auctionID}, update, options.Update().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildFixtures(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputs    []fixtureInput
+		numbers   []int
+		matchdays []int
+		report    []importRowError
+	}{
+		{
+			name: "matchdays follow the local day",
+			inputs: []fixtureInput{
+				{MatchNumber: 3, HomeTeam: "CSK", AwayTeam: "MI", StartTime: "2026-03-29 19:30"},
+				{MatchNumber: 2, HomeTeam: "RCB", AwayTeam: "KKR", StartTime: "2026-03-28 19:30"},
+				{MatchNumber: 1, HomeTeam: "GT", AwayTeam: "PBKS", StartTime: "2026-03-28 15:30"},
+			},
+			numbers:   []int{1, 2, 3},
+			matchdays: []int{1, 1, 2},
+			report:    []importRowError{},
+		},
+		{
+			name: "days are split in the fixture timezone",
+			inputs: []fixtureInput{
+				{MatchNumber: 1, HomeTeam: "GT", AwayTeam: "PBKS", StartTime: "2026-03-28T10:00:00Z"},
+				{MatchNumber: 2, HomeTeam: "RCB", AwayTeam: "KKR", StartTime: "2026-03-28T20:00:00Z"},
+				{MatchNumber: 3, HomeTeam: "CSK", AwayTeam: "MI", StartTime: "2026-03-28T20:00:00Z", Timezone: "UTC"},
+			},
+			numbers:   []int{1, 2, 3},
+			matchdays: []int{1, 2, 3},
+			report:    []importRowError{},
+		},
+		{
+			name: "invalid rows are reported",
+			inputs: []fixtureInput{
+				{MatchNumber: 0, HomeTeam: "CSK", AwayTeam: "csk", StartTime: "2026-03-28 19:30"},
+				{MatchNumber: 1, HomeTeam: "GT", StartTime: "tomorrow"},
+				{MatchNumber: 2, HomeTeam: "RCB", AwayTeam: "KKR", StartTime: "2026-03-28 19:30", Timezone: "Mars/Olympus"},
+			},
+			report: []importRowError{
+				{Row: 1, Errors: []string{"match_number must be a positive integer", "home_team and away_team must differ"}},
+				{Row: 2, Errors: []string{"home_team and away_team are required", `start_time must be RFC 3339 or "YYYY-MM-DD HH:MM" in the timezone`}},
+				{Row: 3, Errors: []string{`timezone "Mars/Olympus" is invalid`}},
+			},
+		},
+		{
+			name: "only accepted rows claim their match number",
+			inputs: []fixtureInput{
+				{MatchNumber: 1, HomeTeam: "GT", AwayTeam: "GT", StartTime: "2026-03-28 15:30"},
+				{MatchNumber: 1, HomeTeam: "GT", AwayTeam: "PBKS", StartTime: "2026-03-28 15:30"},
+				{MatchNumber: 1, HomeTeam: "RCB", AwayTeam: "KKR", StartTime: "2026-03-28 19:30"},
+			},
+			numbers:   []int{1},
+			matchdays: []int{1},
+			report: []importRowError{
+				{Row: 1, Errors: []string{"home_team and away_team must differ"}},
+				{Row: 3, Errors: []string{"match_number 1 duplicates row 2"}},
+			},
+		},
+	}
+
+	auctionID := primitive.NewObjectID()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixtures, report := buildFixtures(tt.inputs, auctionID)
+
+			var numbers, matchdays []int
+			for _, fixture := range fixtures {
+				numbers = append(numbers, fixture.MatchNumber)
+				matchdays = append(matchdays, fixture.Matchday)
+			}
+			if !reflect.DeepEqual(numbers, tt.numbers) {
+				t.Errorf("match numbers = %v, want %v", numbers, tt.numbers)
+			}
+			if !reflect.DeepEqual(matchdays, tt.matchdays) {
+				t.Errorf("matchdays = %v, want %v", matchdays, tt.matchdays)
+			}
+			if !reflect.DeepEqual(report, tt.report) {
+				t.Errorf("report = %+v, want %+v", report, tt.report)
+			}
+		})
+	}
+}
+
+func TestParseFixtureTime(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "rfc 3339 with offset", value: "2026-03-28T19:30:00+05:30", want: time.Date(2026, 3, 28, 14, 0, 0, 0, time.UTC)},
+		{name: "rfc 3339 in utc", value: "2026-03-28T14:00:00Z", want: time.Date(2026, 3, 28, 14, 0, 0, 0, time.UTC)},
+		{name: "wall clock in location", value: "2026-03-28 19:30", want: time.Date(2026, 3, 28, 14, 0, 0, 0, time.UTC)},
+		{name: "surrounding spaces", value: "  2026-03-29 00:15 ", want: time.Date(2026, 3, 28, 18, 45, 0, 0, time.UTC)},
+		{name: "date only", value: "2026-03-28", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFixtureTime(tt.value, kolkata)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseFixtureTime() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFixtureTime() error = %v", err)
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("parseFixtureTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
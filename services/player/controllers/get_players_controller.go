@@ -7,6 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,12 +19,54 @@ import (
 	"go.uber.org/zap"
 )
 
+// playerQueryRequest holds the optional filters, sorting and paging of the player listing
+type playerQueryRequest struct {
+	AuctionID       primitive.ObjectID `json:"auction_id" binding:"required"`
+	Role            string             `json:"role"`
+	Country         string             `json:"country"`
+	Overseas        *bool              `json:"overseas"`
+	Hammer          string             `json:"hammer"`
+	IPLTeam         string             `json:"ipl_team"`
+	CurrentTeam     string             `json:"current_team"`
+	Availability    string             `json:"availability"`
+	MinBasePrice    *float64           `json:"min_base_price"`
+	MaxBasePrice    *float64           `json:"max_base_price"`
+	MinSellingPrice *float64           `json:"min_selling_price"`
+	MaxSellingPrice *float64           `json:"max_selling_price"`
+	Search          string             `json:"search"`
+	Sort            string             `json:"sort"`
+	Order           string             `json:"order"`
+	Page            int64              `json:"page"`
+	Limit           int64              `json:"limit"`
+}
+
+// playerPage is one page of the player listing, as returned and cached
+type playerPage struct {
+	Players []models.Player `json:"players"`
+	Total   int64           `json:"total"`
+	Page    int64           `json:"page"`
+	Limit   int64           `json:"limit"`
+}
+
+// playerSortFields are the fields the player listing can be sorted on
+var playerSortFields = map[string]bool{
+	"player_number": true,
+	"player_name":   true,
+	"role":          true,
+	"country":       true,
+	"ipl_team":      true,
+	"hammer":        true,
+	"current_team":  true,
+	"base_price":    true,
+	"selling_price": true,
+}
+
 // GetAllPlayersController with Redis caching
 func (a *API) GetAllPlayersController(c *gin.Context) {
-	var request struct {
-		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
-	}
-	var players []models.Player
+	var (
+		request playerQueryRequest
+		players []models.Player
+	)
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
 	defer cancel()
@@ -31,16 +77,27 @@ func (a *API) GetAllPlayersController(c *gin.Context) {
 		return
 	}
 
-	// Generate cache key
+	if err := request.normalize(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Generate cache key, unfiltered listings keep the plain auction key
 	cacheKey := fmt.Sprintf(PlayerCacheKey, request.AuctionID.Hex())
+	if query := request.cacheSuffix(); query != "" {
+		cacheKey += ":" + query
+	}
 
 	val, err := a.RedisClient.Get(ctx, cacheKey).Result()
 	if err == nil {
-		var cachedPlayers []models.Player
-		if err = json.Unmarshal([]byte(val), &cachedPlayers); err == nil {
+		var cachedPage playerPage
+		if err = json.Unmarshal([]byte(val), &cachedPage); err == nil {
 			c.JSON(http.StatusOK, gin.H{
 				"message": "Players fetched successfully from cache",
-				"players": cachedPlayers,
+				"players": cachedPage.Players,
+				"total":   cachedPage.Total,
+				"page":    cachedPage.Page,
+				"limit":   cachedPage.Limit,
 			})
 			return
 		} else {
@@ -52,9 +109,23 @@ func (a *API) GetAllPlayersController(c *gin.Context) {
 		}
 	}
 
-	filter := bson.M{"auction_id": request.AuctionID}
+	filter := request.filter()
+
+	total, err := a.MongoDBClient.Collection("players").CountDocuments(ctx, filter)
+	if err != nil {
+		a.logger.Error("failed to count players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
 
-	findOptions := options.Find().SetSort(bson.D{{Key: "player_number", Value: 1}}) // Sort Asc
+	order := 1 // Sort Asc
+	if request.Order == "desc" {
+		order = -1
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: request.Sort, Value: order}, {Key: "_id", Value: order}})
+	if request.Limit > 0 {
+		findOptions.SetSkip((request.Page - 1) * request.Limit).SetLimit(request.Limit)
+	}
 
 	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, filter, findOptions)
 	if err != nil {
@@ -70,9 +141,16 @@ func (a *API) GetAllPlayersController(c *gin.Context) {
 		return
 	}
 
+	page := playerPage{
+		Players: players,
+		Total:   total,
+		Page:    request.Page,
+		Limit:   request.Limit,
+	}
+
 	// Cache the results
 	if len(players) > 0 {
-		jsonData, err := json.Marshal(players)
+		jsonData, err := json.Marshal(page)
 		if err == nil {
 			if err = a.RedisClient.Set(ctx, cacheKey, jsonData, PlayerTTL).Err(); err != nil {
 				a.logger.Warn("failed to set players in redis", zap.Error(err))
@@ -85,5 +163,177 @@ func (a *API) GetAllPlayersController(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Players fetched successfully",
 		"players": players,
+		"total":   total,
+		"page":    request.Page,
+		"limit":   request.Limit,
 	})
 }
+
+// normalize validates the request and fills the defaults, a zero limit returns every player
+func (r *playerQueryRequest) normalize() error {
+	r.Search = strings.TrimSpace(r.Search)
+
+	if r.Sort == "" {
+		r.Sort = "player_number"
+	}
+	if !playerSortFields[r.Sort] {
+		return fmt.Errorf("invalid sort field %q", r.Sort)
+	}
+
+	switch r.Order {
+	case "":
+		r.Order = "asc"
+	case "asc", "desc":
+	default:
+		return fmt.Errorf("invalid sort order %q", r.Order)
+	}
+
+	if r.Role != "" && !models.ValidPlayerRole(r.Role) {
+		return fmt.Errorf("invalid role %q", r.Role)
+	}
+
+	if r.Availability != "" && !models.ValidAvailability(r.Availability) {
+		return fmt.Errorf("invalid availability %q", r.Availability)
+	}
+
+	if r.Limit < 0 || r.Page < 0 {
+		return fmt.Errorf("invalid page or limit")
+	}
+	if r.Limit > MaxPlayerPageSize {
+		r.Limit = MaxPlayerPageSize
+	}
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	return nil
+}
+
+// filter builds the mongo filter of the request
+func (r playerQueryRequest) filter() bson.M {
+	filter := bson.M{"auction_id": r.AuctionID}
+
+	if r.Role != "" {
+		filter["role"] = r.Role
+	}
+	if r.Hammer != "" {
+		filter["hammer"] = r.Hammer
+	}
+	if r.IPLTeam != "" {
+		filter["ipl_team"] = r.IPLTeam
+	}
+	if r.CurrentTeam != "" {
+		filter["current_team"] = r.CurrentTeam
+	}
+	// Available players have no availability set
+	switch r.Availability {
+	case "":
+	case models.AvailabilityAvailable:
+		filter["availability"] = bson.M{"$exists": false}
+	default:
+		filter["availability.status"] = r.Availability
+	}
+
+	if r.Country != "" {
+		filter["country"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(r.Country) + "$", Options: "i"}
+	} else if r.Overseas != nil {
+		home := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(HomeCountry) + "$", Options: "i"}
+		if *r.Overseas {
+			filter["country"] = bson.M{"$exists": true, "$ne": "", "$not": home}
+		} else {
+			filter["country"] = home
+		}
+	}
+
+	if r.Search != "" {
+		filter["player_name"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(r.Search), Options: "i"}
+	}
+
+	if priceRange := rangeFilter(r.MinBasePrice, r.MaxBasePrice); priceRange != nil {
+		filter["base_price"] = priceRange
+	}
+	if priceRange := rangeFilter(r.MinSellingPrice, r.MaxSellingPrice); priceRange != nil {
+		filter["selling_price"] = priceRange
+	}
+
+	return filter
+}
+
+// cacheSuffix returns the normalized query used in cache keys, empty for the default listing
+func (r playerQueryRequest) cacheSuffix() string {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	setFloat := func(key string, value *float64) {
+		if value != nil {
+			values.Set(key, strconv.FormatFloat(*value, 'f', -1, 64))
+		}
+	}
+
+	set("role", r.Role)
+	set("country", strings.ToLower(r.Country))
+	set("hammer", r.Hammer)
+	set("ipl_team", r.IPLTeam)
+	set("current_team", r.CurrentTeam)
+	set("availability", r.Availability)
+	set("search", strings.ToLower(r.Search))
+	if r.Overseas != nil {
+		values.Set("overseas", strconv.FormatBool(*r.Overseas))
+	}
+	setFloat("min_base_price", r.MinBasePrice)
+	setFloat("max_base_price", r.MaxBasePrice)
+	setFloat("min_selling_price", r.MinSellingPrice)
+	setFloat("max_selling_price", r.MaxSellingPrice)
+	if r.Sort != "player_number" || r.Order != "asc" {
+		values.Set("sort", r.Sort)
+		values.Set("order", r.Order)
+	}
+	if r.Limit > 0 {
+		values.Set("page", strconv.FormatInt(r.Page, 10))
+		values.Set("limit", strconv.FormatInt(r.Limit, 10))
+	}
+	return values.Encode() // Encode sorts by key
+}
+
+// rangeFilter returns the $gte/$lte filter of the bounds, nil when both are unset
+func rangeFilter(min, max *float64) bson.M {
+	if min == nil && max == nil {
+		return nil
+	}
+
+	bounds := bson.M{}
+	if min != nil {
+		bounds["$gte"] = *min
+	}
+	if max != nil {
+		bounds["$lte"] = *max
+	}
+	return bounds
+}
+
+// clearPlayerCache deletes every cached player listing of the given auctions
+func (a *API) clearPlayerCache(ctx context.Context, auctionIDs ...primitive.ObjectID) error {
+	var cacheKeys []string
+
+	for _, auctionID := range auctionIDs {
+		baseKey := fmt.Sprintf(PlayerCacheKey, auctionID.Hex())
+		cacheKeys = append(cacheKeys, baseKey)
+
+		// SCAN walks the keyspace in batches where KEYS would block redis
+		iter := a.RedisClient.Scan(ctx, 0, baseKey+":*", ScanBatchSize).Iterator()
+		for iter.Next(ctx) {
+			cacheKeys = append(cacheKeys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
+
+	if len(cacheKeys) == 0 {
+		return nil
+	}
+	_, err := a.RedisClient.Del(ctx, cacheKeys...).Result()
+	return err
+}
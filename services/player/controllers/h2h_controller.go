@@ -0,0 +1,436 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// h2hResult is a matchup with its score once its matchday has closed
+type h2hResult struct {
+	models.H2HMatchup
+	HomeName   string              `json:"home_name"`
+	AwayName   string              `json:"away_name,omitempty"`
+	HomePoints int                 `json:"home_points"`
+	AwayPoints int                 `json:"away_points"`
+	Winner     *primitive.ObjectID `json:"winner,omitempty"` // nil for a draw or a matchup still to play
+	Completed  bool                `json:"completed"`
+}
+
+// h2hStanding is the record of one team in the head-to-head league
+type h2hStanding struct {
+	TeamID        primitive.ObjectID `json:"team_id"`
+	TeamName      string             `json:"team_name"`
+	Rank          int                `json:"rank"`
+	Played        int                `json:"played"`
+	Won           int                `json:"won"`
+	Drawn         int                `json:"drawn"`
+	Lost          int                `json:"lost"`
+	Points        int                `json:"points"`
+	PointsFor     int                `json:"points_for"`
+	PointsAgainst int                `json:"points_against"`
+}
+
+// CreateH2HScheduleController draws a round-robin head-to-head schedule between the
+// teams of an IPL auction, one round per matchday from the start matchday, with an
+// optional playoff bracket for the top teams once the league is over. Drawing it
+// again replaces the previous schedule.
+func (a *API) CreateH2HScheduleController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID     primitive.ObjectID `json:"auction_id" binding:"required"`
+			StartMatchday *int               `json:"start_matchday"`
+			Legs          int                `json:"legs"`
+			PlayoffTeams  int                `json:"playoff_teams"`
+		}
+		auction models.Auction
+		teams   []models.Team
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind h2h schedule request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.Legs == 0 {
+		request.Legs = 1
+	}
+	if request.Legs < 0 || request.Legs > MaxH2HLegs {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid number of legs"})
+		return
+	}
+	if !models.ValidPlayoffTeams(request.PlayoffTeams) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Playoff teams must be a power of two"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpScoreMatches, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+	if err != nil {
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !auction.IsIPLAuction {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Head-to-head leagues are only run for IPL auctions"})
+		return
+	}
+
+	season, err := a.fantasySeason(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to find fantasy season", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	startMatchday := season.Matchday + 1
+	if request.StartMatchday != nil {
+		startMatchday = *request.StartMatchday
+	}
+	// Matchdays already closed cannot hold matchups anymore
+	if startMatchday < season.Matchday {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Schedule cannot start before the matchday in play"})
+		return
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("teams").Find(ctx, bson.M{"auction_id": request.AuctionID}, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch teams", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &teams); err != nil {
+		a.logger.Error("failed to decode teams", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+	if len(teams) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Head-to-head needs at least two teams"})
+		return
+	}
+	if request.PlayoffTeams > len(teams) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "More playoff teams than teams in the auction"})
+		return
+	}
+
+	teamIDs := make([]primitive.ObjectID, 0, len(teams))
+	for _, team := range teams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	now := time.Now()
+	league := models.H2HLeague{
+		AuctionID:     request.AuctionID,
+		Teams:         teamIDs,
+		StartMatchday: startMatchday,
+		Legs:          request.Legs,
+		PlayoffTeams:  request.PlayoffTeams,
+		Matchups:      []models.H2HMatchup{},
+		CreatedBy:     c.GetString("email"),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	for round, pairs := range utils.RoundRobin(teamIDs, request.Legs) {
+		for _, pair := range pairs {
+			league.Matchups = append(league.Matchups, models.H2HMatchup{
+				Matchday: startMatchday + round,
+				Round:    round + 1,
+				Stage:    models.H2HStageLeague,
+				HomeTeam: pair[0],
+				AwayTeam: pair[1],
+			})
+		}
+	}
+
+	opts := options.Replace().SetUpsert(true)
+	if _, err = a.MongoDBClient.Collection("h2h_leagues").ReplaceOne(ctx, bson.M{"_id": request.AuctionID}, league, opts); err != nil {
+		a.logger.Error("failed to save h2h schedule", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Head-to-head schedule created successfully",
+		"league":  league,
+	})
+}
+
+// GetH2HLeagueController returns the head-to-head schedule of an auction with the
+// scores of the played matchups, the league table and the playoff bracket
+func (a *API) GetH2HLeagueController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+		league    models.H2HLeague
+		teams     []models.Team
+		snapshots []models.MatchdaySnapshot
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get h2h league request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("h2h_leagues").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&league)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No head-to-head schedule for this auction"})
+			return
+		}
+		a.logger.Error("failed to find h2h league", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	cursor, err := a.MongoDBClient.Collection("teams").Find(ctx, bson.M{"auction_id": request.AuctionID})
+	if err != nil {
+		a.logger.Error("failed to fetch teams", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &teams); err != nil {
+		a.logger.Error("failed to decode teams", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+	names := make(map[primitive.ObjectID]string, len(teams))
+	for _, team := range teams {
+		names[team.ID] = team.TeamName
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "matchday", Value: 1}})
+	cursor, err = a.MongoDBClient.Collection("matchday_snapshots").Find(ctx, bson.M{"auction_id": request.AuctionID}, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch matchday snapshots", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		a.logger.Error("failed to decode matchday snapshots", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	points := matchdayPoints(snapshots)
+	results := make([]h2hResult, 0, len(league.Matchups))
+	for _, matchup := range league.Matchups {
+		results = append(results, scoreMatchup(matchup, points, names))
+	}
+	table := h2hTable(league.Teams, names, results)
+	playoffs, champion := playoffResults(league, table, points, names)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Head-to-head league fetched successfully",
+		"league":   league,
+		"matchups": results,
+		"table":    table,
+		"playoffs": playoffs,
+		"champion": champion,
+	})
+}
+
+// matchdayPoints returns the points each team earned in every closed matchday. A
+// player counts for the team owning it when the matchday closed, with the points
+// earned since the previous snapshot.
+func matchdayPoints(snapshots []models.MatchdaySnapshot) map[int]map[primitive.ObjectID]int {
+	points := make(map[int]map[primitive.ObjectID]int, len(snapshots))
+	earned := make(map[primitive.ObjectID]int)
+
+	for _, snapshot := range snapshots {
+		teamPoints := make(map[primitive.ObjectID]int, len(snapshot.Teams))
+		for _, player := range snapshot.Players {
+			if !player.TeamID.IsZero() {
+				teamPoints[player.TeamID] += player.EarnedPoints - earned[player.PlayerID]
+			}
+			earned[player.PlayerID] = player.EarnedPoints
+		}
+		points[snapshot.Matchday] = teamPoints
+	}
+	return points
+}
+
+// scoreMatchup fills in the score of the matchup once its matchday has closed
+func scoreMatchup(matchup models.H2HMatchup, points map[int]map[primitive.ObjectID]int, names map[primitive.ObjectID]string) h2hResult {
+	result := h2hResult{
+		H2HMatchup: matchup,
+		HomeName:   names[matchup.HomeTeam],
+		AwayName:   names[matchup.AwayTeam],
+	}
+
+	teamPoints, closed := points[matchup.Matchday]
+	if !closed {
+		return result
+	}
+	result.Completed = true
+	result.HomePoints = teamPoints[matchup.HomeTeam]
+	if matchup.AwayTeam.IsZero() {
+		return result
+	}
+	result.AwayPoints = teamPoints[matchup.AwayTeam]
+
+	winner := primitive.NilObjectID
+	switch {
+	case result.HomePoints > result.AwayPoints:
+		winner = matchup.HomeTeam
+	case result.AwayPoints > result.HomePoints:
+		winner = matchup.AwayTeam
+	}
+	if !winner.IsZero() {
+		result.Winner = &winner
+	}
+	return result
+}
+
+// h2hTable builds the league table from the completed league matchups. Teams are
+// ordered by league points, then by fantasy points scored, then by the difference
+// between points scored and conceded, then by wins.
+func h2hTable(teamIDs []primitive.ObjectID, names map[primitive.ObjectID]string, results []h2hResult) []h2hStanding {
+	standings := make([]h2hStanding, 0, len(teamIDs))
+	byTeam := make(map[primitive.ObjectID]int, len(teamIDs))
+	for _, teamID := range teamIDs {
+		byTeam[teamID] = len(standings)
+		standings = append(standings, h2hStanding{TeamID: teamID, TeamName: names[teamID]})
+	}
+
+	record := func(teamID primitive.ObjectID, scored, conceded int, winner *primitive.ObjectID) {
+		i, ok := byTeam[teamID]
+		if !ok {
+			return
+		}
+		standing := &standings[i]
+		standing.Played++
+		standing.PointsFor += scored
+		standing.PointsAgainst += conceded
+		switch {
+		case winner == nil:
+			standing.Drawn++
+			standing.Points += H2HDrawPoints
+		case *winner == teamID:
+			standing.Won++
+			standing.Points += H2HWinPoints
+		default:
+			standing.Lost++
+		}
+	}
+
+	for _, result := range results {
+		if result.Stage != models.H2HStageLeague || !result.Completed || result.AwayTeam.IsZero() {
+			continue
+		}
+		record(result.HomeTeam, result.HomePoints, result.AwayPoints, result.Winner)
+		record(result.AwayTeam, result.AwayPoints, result.HomePoints, result.Winner)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.PointsFor != b.PointsFor {
+			return a.PointsFor > b.PointsFor
+		}
+		if a.PointsFor-a.PointsAgainst != b.PointsFor-b.PointsAgainst {
+			return a.PointsFor-a.PointsAgainst > b.PointsFor-b.PointsAgainst
+		}
+		if a.Won != b.Won {
+			return a.Won > b.Won
+		}
+		return a.TeamName < b.TeamName
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// playoffResults draws the playoff bracket from the final table once the last league
+// round has closed, each playoff round being played on the matchday after the one
+// before. The higher seed hosts and goes through on a drawn matchup. The champion is
+// set once the final has been played.
+func playoffResults(league models.H2HLeague, table []h2hStanding, points map[int]map[primitive.ObjectID]int, names map[primitive.ObjectID]string) ([]h2hResult, *primitive.ObjectID) {
+	results := []h2hResult{}
+	lastMatchday := league.LastLeagueMatchday()
+	if league.PlayoffTeams < 2 || len(table) < league.PlayoffTeams {
+		return results, nil
+	}
+	if _, closed := points[lastMatchday]; !closed {
+		return results, nil
+	}
+
+	// Seeds in bracket order so the top two seeds can only meet in the final
+	order := []int{1}
+	for size := 2; size <= league.PlayoffTeams; size *= 2 {
+		next := make([]int, 0, size)
+		for _, seed := range order {
+			next = append(next, seed, size+1-seed)
+		}
+		order = next
+	}
+	alive := make([]primitive.ObjectID, 0, len(order))
+	seedOf := make(map[primitive.ObjectID]int, len(order))
+	for _, seed := range order {
+		teamID := table[seed-1].TeamID
+		alive = append(alive, teamID)
+		seedOf[teamID] = seed
+	}
+
+	for round := 1; len(alive) > 1; round++ {
+		winners := make([]primitive.ObjectID, 0, len(alive)/2)
+		for i := 0; i < len(alive); i += 2 {
+			home, away := alive[i], alive[i+1]
+			if seedOf[away] < seedOf[home] {
+				home, away = away, home
+			}
+			result := scoreMatchup(models.H2HMatchup{
+				Matchday: lastMatchday + round,
+				Round:    round,
+				Stage:    models.H2HStagePlayoff,
+				HomeTeam: home,
+				AwayTeam: away,
+			}, points, names)
+			if result.Completed && result.Winner == nil {
+				result.Winner = &home
+			}
+			results = append(results, result)
+			if result.Winner != nil {
+				winners = append(winners, *result.Winner)
+			}
+		}
+
+		// The next round is drawn once every matchup of this one is decided
+		if len(winners) < len(alive)/2 {
+			return results, nil
+		}
+		alive = winners
+	}
+
+	champion := alive[0]
+	return results, &champion
+}
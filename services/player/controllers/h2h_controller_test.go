@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"auction-web/pkg/models"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMatchdayPoints(t *testing.T) {
+	titans, kings := primitive.NewObjectID(), primitive.NewObjectID()
+	kohli, bumrah, free := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	snapshot := func(matchday int, players ...models.PlayerSnapshot) models.MatchdaySnapshot {
+		return models.MatchdaySnapshot{Matchday: matchday, Players: players}
+	}
+
+	tests := []struct {
+		name      string
+		snapshots []models.MatchdaySnapshot
+		want      map[int]map[primitive.ObjectID]int
+	}{
+		{
+			name:      "no closed matchday",
+			snapshots: []models.MatchdaySnapshot{},
+			want:      map[int]map[primitive.ObjectID]int{},
+		},
+		{
+			name: "points earned since the previous snapshot",
+			snapshots: []models.MatchdaySnapshot{
+				snapshot(1,
+					models.PlayerSnapshot{PlayerID: kohli, TeamID: titans, EarnedPoints: 40},
+					models.PlayerSnapshot{PlayerID: bumrah, TeamID: kings, EarnedPoints: 25},
+				),
+				snapshot(2,
+					models.PlayerSnapshot{PlayerID: kohli, TeamID: titans, EarnedPoints: 70},
+					models.PlayerSnapshot{PlayerID: bumrah, TeamID: kings, EarnedPoints: 25},
+				),
+			},
+			want: map[int]map[primitive.ObjectID]int{
+				1: {titans: 40, kings: 25},
+				2: {titans: 30, kings: 0},
+			},
+		},
+		{
+			name: "traded and released players count for the team owning them",
+			snapshots: []models.MatchdaySnapshot{
+				snapshot(1,
+					models.PlayerSnapshot{PlayerID: kohli, TeamID: titans, EarnedPoints: 40},
+					models.PlayerSnapshot{PlayerID: free, EarnedPoints: 15},
+				),
+				snapshot(2,
+					models.PlayerSnapshot{PlayerID: kohli, TeamID: kings, EarnedPoints: 55},
+					models.PlayerSnapshot{PlayerID: free, TeamID: titans, EarnedPoints: 35},
+				),
+			},
+			want: map[int]map[primitive.ObjectID]int{
+				1: {titans: 40},
+				2: {kings: 15, titans: 20},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchdayPoints(tt.snapshots); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchdayPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestH2HTable(t *testing.T) {
+	ids := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	names := map[primitive.ObjectID]string{ids[0]: "Titans", ids[1]: "Kings", ids[2]: "Royals", ids[3]: "Capitals"}
+	played := func(home, away, homePoints, awayPoints int) h2hResult {
+		return scoreMatchup(
+			models.H2HMatchup{Matchday: 1, Stage: models.H2HStageLeague, HomeTeam: ids[home], AwayTeam: ids[away]},
+			map[int]map[primitive.ObjectID]int{1: {ids[home]: homePoints, ids[away]: awayPoints}},
+			names,
+		)
+	}
+
+	tests := []struct {
+		name    string
+		results []h2hResult
+		want    []string
+	}{
+		{
+			name: "nothing played orders by name",
+			want: []string{"Capitals", "Kings", "Royals", "Titans"},
+		},
+		{
+			name:    "points scored break a tie on league points",
+			results: []h2hResult{played(0, 1, 50, 40), played(2, 3, 60, 30)},
+			want:    []string{"Royals", "Titans", "Kings", "Capitals"},
+		},
+		{
+			name:    "difference breaks a tie on points scored",
+			results: []h2hResult{played(0, 1, 50, 40), played(2, 3, 50, 20)},
+			want:    []string{"Royals", "Titans", "Kings", "Capitals"},
+		},
+		{
+			name:    "a draw beats a loss",
+			results: []h2hResult{played(0, 1, 45, 45), played(2, 3, 70, 20)},
+			want:    []string{"Royals", "Kings", "Titans", "Capitals"},
+		},
+		{
+			name: "byes, playoffs and matchups still to play are left out",
+			results: []h2hResult{
+				played(3, 0, 30, 20),
+				{H2HMatchup: models.H2HMatchup{Stage: models.H2HStageLeague, HomeTeam: ids[0]}, HomePoints: 90, Completed: true},
+				{H2HMatchup: models.H2HMatchup{Stage: models.H2HStageLeague, HomeTeam: ids[1], AwayTeam: ids[2]}},
+				func() h2hResult {
+					result := played(1, 2, 80, 10)
+					result.Stage = models.H2HStagePlayoff
+					return result
+				}(),
+			},
+			want: []string{"Capitals", "Titans", "Kings", "Royals"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := h2hTable(ids, names, tt.results)
+
+			got := make([]string, 0, len(table))
+			for i, standing := range table {
+				if standing.Rank != i+1 {
+					t.Errorf("%s has rank %d, want %d", standing.TeamName, standing.Rank, i+1)
+				}
+				if standing.Points != standing.Won*H2HWinPoints+standing.Drawn*H2HDrawPoints {
+					t.Errorf("%s has %d points for %d wins and %d draws", standing.TeamName, standing.Points, standing.Won, standing.Drawn)
+				}
+				got = append(got, standing.TeamName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("h2hTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayoffResults(t *testing.T) {
+	seeds := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()}
+	table := make([]h2hStanding, 0, len(seeds))
+	seedOf := make(map[primitive.ObjectID]int, len(seeds))
+	for i, teamID := range seeds {
+		table = append(table, h2hStanding{TeamID: teamID, Rank: i + 1})
+		seedOf[teamID] = i + 1
+	}
+	league := models.H2HLeague{
+		StartMatchday: 1,
+		PlayoffTeams:  4,
+		Matchups: []models.H2HMatchup{
+			{Matchday: 1, Stage: models.H2HStageLeague},
+			{Matchday: 3, Stage: models.H2HStageLeague},
+		},
+	}
+	// points per matchday by seed
+	closed := func(matchdays map[int][]int) map[int]map[primitive.ObjectID]int {
+		points := make(map[int]map[primitive.ObjectID]int, len(matchdays))
+		for matchday, bySeed := range matchdays {
+			teamPoints := make(map[primitive.ObjectID]int, len(bySeed))
+			for i, p := range bySeed {
+				teamPoints[seeds[i]] = p
+			}
+			points[matchday] = teamPoints
+		}
+		return points
+	}
+
+	// matchup is a playoff matchup by seed, a zero winner is one still to play
+	type matchup struct {
+		round, home, away, winner int
+	}
+
+	tests := []struct {
+		name         string
+		league       models.H2HLeague
+		points       map[int]map[primitive.ObjectID]int
+		want         []matchup
+		wantChampion int
+	}{
+		{
+			name:   "no playoffs",
+			league: models.H2HLeague{StartMatchday: 1, Matchups: league.Matchups},
+			points: closed(map[int][]int{3: {}}),
+			want:   []matchup{},
+		},
+		{
+			name:   "league still running",
+			league: league,
+			points: closed(map[int][]int{1: {}}),
+			want:   []matchup{},
+		},
+		{
+			name:   "semi-finals drawn by seed",
+			league: league,
+			points: closed(map[int][]int{3: {}}),
+			want:   []matchup{{1, 1, 4, 0}, {1, 2, 3, 0}},
+		},
+		{
+			name:   "higher seed goes through on a draw",
+			league: league,
+			points: closed(map[int][]int{3: {}, 4: {40, 50, 50, 60}}),
+			want:   []matchup{{1, 1, 4, 4}, {1, 2, 3, 2}, {2, 2, 4, 0}},
+		},
+		{
+			name:         "champion once the final is played",
+			league:       league,
+			points:       closed(map[int][]int{3: {}, 4: {40, 50, 50, 60}, 5: {0, 30, 0, 45}}),
+			want:         []matchup{{1, 1, 4, 4}, {1, 2, 3, 2}, {2, 2, 4, 4}},
+			wantChampion: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, champion := playoffResults(tt.league, table, tt.points, nil)
+
+			got := make([]matchup, 0, len(results))
+			for _, result := range results {
+				winner := 0
+				if result.Winner != nil {
+					winner = seedOf[*result.Winner]
+				}
+				if result.Matchday != league.LastLeagueMatchday()+result.Round {
+					t.Errorf("round %d is played on matchday %d", result.Round, result.Matchday)
+				}
+				got = append(got, matchup{result.Round, seedOf[result.HomeTeam], seedOf[result.AwayTeam], winner})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("playoffResults() = %v, want %v", got, tt.want)
+			}
+
+			gotChampion := 0
+			if champion != nil {
+				gotChampion = seedOf[*champion]
+			}
+			if gotChampion != tt.wantChampion {
+				t.Errorf("playoffResults() champion = seed %d, want seed %d", gotChampion, tt.wantChampion)
+			}
+		})
+	}
+}
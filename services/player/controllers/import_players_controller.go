@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// importRowError is the validation report of a single row of the import file
+type importRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+// ImportPlayersController imports players of an auction from a CSV/XLSX file
+func (a *API) ImportPlayersController(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	auctionID, err := primitive.ObjectIDFromHex(c.PostForm("auction_id"))
+	if err != nil {
+		a.logger.Error("failed to parse auction id of import request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid auction id"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManagePlayers, auctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", auctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	isIPLAuction := c.Query("isIPLAuction")
+	if isIPLAuction == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request query params"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		a.logger.Error("failed to read import file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		a.logger.Error("failed to open import file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to open file"})
+		return
+	}
+	defer file.Close()
+
+	rows, err := readImportRows(file, fileHeader.Filename)
+	if err != nil {
+		a.logger.Error("failed to parse import file", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(rows) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File has no player rows"})
+		return
+	}
+
+	// Player numbers already present in the auction count as duplicates too
+	existingNumbers, err := a.fetchPlayerNumbers(ctx, auctionID)
+	if err != nil {
+		a.logger.Error("failed to fetch existing player numbers", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	players, report := parseImportRows(rows, auctionID, existingNumbers)
+
+	if dryRun || len(players) == 0 {
+		status, message := http.StatusOK, "Players validated successfully"
+		if len(players) == 0 {
+			status, message = http.StatusBadRequest, "No valid player rows to import"
+		}
+		c.JSON(status, gin.H{
+			"message":    message,
+			"dry_run":    dryRun,
+			"total_rows": len(rows) - 1,
+			"valid_rows": len(players),
+			"inserted":   0,
+			"errors":     report,
+		})
+		return
+	}
+
+	if err = a.insertPlayers(ctx, auctionID, players, isIPLAuction == "true", "players:import", c.GetHeader(IdempotencyHeader)); err != nil {
+		if errors.Is(err, errDuplicateRequest) {
+			c.JSON(http.StatusOK, gin.H{"message": "Players already imported for this request"})
+			return
+		}
+		a.logger.Error("failed to import players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save players"})
+		return
+	}
+
+	if err = a.clearPlayerCache(ctx, auctionID); err != nil {
+		a.logger.Warn("failed to clear player cache after import", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Players imported successfully",
+		"dry_run":    false,
+		"total_rows": len(rows) - 1,
+		"valid_rows": len(players),
+		"inserted":   len(players),
+		"errors":     report,
+	})
+}
+
+// readImportRows reads all the rows of a CSV or XLSX file, header included
+func readImportRows(file io.Reader, filename string) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case ".xlsx":
+		workbook, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer workbook.Close()
+
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("workbook has no sheets")
+		}
+		return workbook.GetRows(sheets[0])
+	default:
+		return nil, errors.New("only .csv and .xlsx files are supported")
+	}
+}
+
+// fetchPlayerNumbers returns the player numbers already used in an auction
+func (a *API) fetchPlayerNumbers(ctx context.Context, auctionID primitive.ObjectID) (map[int]bool, error) {
+	values, err := a.MongoDBClient.Collection("players").Distinct(ctx, "player_number", bson.M{"auction_id": auctionID})
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make(map[int]bool, len(values))
+	for _, value := range values {
+		switch n := value.(type) {
+		case int32:
+			numbers[int(n)] = true
+		case int64:
+			numbers[int(n)] = true
+		}
+	}
+	return numbers, nil
+}
+
+// parseImportRows maps the file columns to players and validates every row
+func parseImportRows(rows [][]string, auctionID primitive.ObjectID, existingNumbers map[int]bool) ([]models.Player, []importRowError) {
+	var (
+		players []models.Player
+		report  = []importRowError{}
+		columns = make(map[string]int)
+		seen    = make(map[int]int)
+	)
+
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+
+	for i, row := range rows[1:] {
+		rowNumber := i + 2 // 1-based and after the header
+		cell := func(column string) string {
+			idx, ok := columns[column]
+			if !ok || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+
+		var rowErrors []string
+		player := models.Player{
+			AuctionId:   auctionID,
+			PlayerName:  cell("player_name"),
+			Country:     cell("country"),
+			Role:        cell("role"),
+			PrevTeam:    cell("prev_team"),
+			IPLTeam:     cell("ipl_team"),
+			CurrentTeam: "",
+			Hammer:      "upcoming",
+			Bids:        []models.Bids{},
+		}
+
+		if value := cell("player_number"); value == "" {
+			rowErrors = append(rowErrors, "player_number is required")
+		} else if number, err := strconv.Atoi(value); err != nil || number <= 0 {
+			rowErrors = append(rowErrors, "player_number must be a positive integer")
+		} else if existingNumbers[number] {
+			rowErrors = append(rowErrors, fmt.Sprintf("player_number %d already exists in auction", number))
+		} else if prev, ok := seen[number]; ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("player_number %d duplicates row %d", number, prev))
+		} else {
+			player.PlayerNumber = number
+		}
+
+		if player.PlayerName == "" {
+			rowErrors = append(rowErrors, "player_name is required")
+		}
+
+		if player.Role == "" {
+			rowErrors = append(rowErrors, "role is required")
+		} else if !models.ValidPlayerRole(player.Role) {
+			rowErrors = append(rowErrors, fmt.Sprintf("role %q is invalid", player.Role))
+		}
+
+		if value := cell("base_price"); value == "" {
+			rowErrors = append(rowErrors, "base_price is required")
+		} else if price, err := strconv.ParseFloat(value, 64); err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+			rowErrors = append(rowErrors, "base_price must be a non-negative number")
+		} else {
+			player.BasePrice = price
+		}
+
+		if value := cell("catalog_id"); value != "" {
+			catalogID, err := primitive.ObjectIDFromHex(value)
+			if err != nil {
+				rowErrors = append(rowErrors, "catalog_id is not a valid id")
+			}
+			player.CatalogId = catalogID
+		}
+
+		if value := cell("prev_fantasy_points"); value != "" {
+			points, err := strconv.Atoi(value)
+			if err != nil {
+				rowErrors = append(rowErrors, "prev_fantasy_points must be an integer")
+			}
+			player.PrevFantasyPoints = points
+		}
+
+		if len(rowErrors) > 0 {
+			report = append(report, importRowError{Row: rowNumber, Errors: rowErrors})
+			continue
+		}
+		// Only accepted rows claim their number, a rejected row must not shadow a later valid one
+		seen[player.PlayerNumber] = rowNumber
+		players = append(players, player)
+	}
+
+	return players, report
+}
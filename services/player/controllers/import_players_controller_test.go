@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseImportRows(t *testing.T) {
+	header := []string{"Player Number", "Player Name", "Role", "Base Price", "Country"}
+
+	tests := []struct {
+		name     string
+		rows     [][]string
+		existing map[int]bool
+		numbers  []int
+		report   []importRowError
+	}{
+		{
+			name: "valid rows",
+			rows: [][]string{
+				header,
+				{"1", "Virat Kohli", "Batter", "200", "India"},
+				{"2", "Jasprit Bumrah", "Bowler", "150", "India"},
+			},
+			numbers: []int{1, 2},
+			report:  []importRowError{},
+		},
+		{
+			name: "number already in auction",
+			rows: [][]string{
+				header,
+				{"7", "MS Dhoni", "Wicket-Keeper", "100"},
+			},
+			existing: map[int]bool{7: true},
+			report: []importRowError{
+				{Row: 2, Errors: []string{"player_number 7 already exists in auction"}},
+			},
+		},
+		{
+			name: "duplicate of accepted row",
+			rows: [][]string{
+				header,
+				{"3", "Rohit Sharma", "Batter", "180"},
+				{"3", "Hardik Pandya", "All-Rounder", "160"},
+			},
+			numbers: []int{3},
+			report: []importRowError{
+				{Row: 3, Errors: []string{"player_number 3 duplicates row 2"}},
+			},
+		},
+		{
+			name: "rejected row does not claim its number",
+			rows: [][]string{
+				header,
+				{"4", "", "Batter", "100"},
+				{"4", "Shubman Gill", "Batter", "120"},
+			},
+			numbers: []int{4},
+			report: []importRowError{
+				{Row: 2, Errors: []string{"player_name is required"}},
+			},
+		},
+		{
+			name: "invalid fields",
+			rows: [][]string{
+				header,
+				{"x", "Rashid Khan", "spinner", "-1"},
+				{"", "Kagiso Rabada", "", ""},
+			},
+			report: []importRowError{
+				{Row: 2, Errors: []string{
+					"player_number must be a positive integer",
+					`role "spinner" is invalid`,
+					"base_price must be a non-negative number",
+				}},
+				{Row: 3, Errors: []string{
+					"player_number is required",
+					"role is required",
+					"base_price is required",
+				}},
+			},
+		},
+		{
+			name: "non-finite base price",
+			rows: [][]string{
+				header,
+				{"5", "Suryakumar Yadav", "Batter", "NaN"},
+				{"6", "Ravindra Jadeja", "All-Rounder", "+Inf"},
+				{"8", "Mohammed Siraj", "Bowler", "-nan"},
+			},
+			report: []importRowError{
+				{Row: 2, Errors: []string{"base_price must be a non-negative number"}},
+				{Row: 3, Errors: []string{"base_price must be a non-negative number"}},
+				{Row: 4, Errors: []string{"base_price must be a non-negative number"}},
+			},
+		},
+	}
+
+	auctionID := primitive.NewObjectID()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players, report := parseImportRows(tt.rows, auctionID, tt.existing)
+
+			var numbers []int
+			for _, player := range players {
+				if player.AuctionId != auctionID {
+					t.Errorf("player %q has auction %s, want %s", player.PlayerName, player.AuctionId.Hex(), auctionID.Hex())
+				}
+				numbers = append(numbers, player.PlayerNumber)
+			}
+			if !reflect.DeepEqual(numbers, tt.numbers) {
+				t.Errorf("player numbers = %v, want %v", numbers, tt.numbers)
+			}
+			if !reflect.DeepEqual(report, tt.report) {
+				t.Errorf("report = %+v, want %+v", report, tt.report)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// leaderboardEntry is the standing of one team
+type leaderboardEntry struct {
+	TeamID        primitive.ObjectID `bson:"_id" json:"team_id"`
+	TeamName      string             `bson:"team_name" json:"team_name"`
+	TotalPoints   int                `bson:"total_points" json:"total_points"`
+	EarnedPoints  int                `bson:"earned_points" json:"earned_points"`
+	BenchedPoints int                `bson:"benched_points" json:"benched_points"`
+	Rank          int                `bson:"-" json:"rank"`
+	PrevRank      int                `bson:"-" json:"prev_rank,omitempty"`
+	Movement      int                `bson:"-" json:"movement"` // places gained since the previous matchday
+	Timeline      []timelinePoint    `bson:"-" json:"timeline"`
+}
+
+// timelinePoint is the standing of a team at the close of a matchday
+type timelinePoint struct {
+	Matchday      int `json:"matchday"`
+	Rank          int `json:"rank"`
+	TotalPoints   int `json:"total_points"`
+	EarnedPoints  int `json:"earned_points"`
+	BenchedPoints int `json:"benched_points"`
+}
+
+// leaderboard is the standings of an auction, as returned and cached
+type leaderboard struct {
+	Matchday int                `json:"matchday"`
+	Teams    []leaderboardEntry `json:"teams"`
+}
+
+// LeaderboardController ranks the teams of an IPL auction by the fantasy points of
+// their squads, with the movement since the previous matchday and a timeline per team
+func (a *API) LeaderboardController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+		auction models.Auction
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind leaderboard request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	cacheKey := fmt.Sprintf(LeaderboardCacheKey, request.AuctionID.Hex())
+	val, err := a.RedisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var cached leaderboard
+		if err = json.Unmarshal([]byte(val), &cached); err == nil {
+			c.JSON(http.StatusOK, gin.H{
+				"message":     "Leaderboard fetched successfully from cache",
+				"matchday":    cached.Matchday,
+				"leaderboard": cached.Teams,
+			})
+			return
+		}
+		a.logger.Warn("failed to unmarshal leaderboard from cache", zap.Error(err))
+	}
+
+	err = a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Auction not found"})
+			return
+		}
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !auction.IsIPLAuction {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Leaderboards are only kept for IPL auctions"})
+		return
+	}
+
+	board, err := a.buildLeaderboard(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to build leaderboard", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	if jsonData, err := json.Marshal(board); err == nil {
+		if err = a.RedisClient.Set(ctx, cacheKey, jsonData, LeaderboardTTL).Err(); err != nil {
+			a.logger.Warn("failed to set leaderboard in redis", zap.Error(err))
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Leaderboard fetched successfully",
+		"matchday":    board.Matchday,
+		"leaderboard": board.Teams,
+	})
+}
+
+// buildLeaderboard sums the match documents of every squad and ranks the teams,
+// the rank history comes from the matchday snapshots
+func (a *API) buildLeaderboard(ctx context.Context, auctionID primitive.ObjectID) (leaderboard, error) {
+	var (
+		board     = leaderboard{Teams: []leaderboardEntry{}}
+		season    models.FantasySeason
+		snapshots []models.MatchdaySnapshot
+	)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"auction_id": auctionID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "players",
+			"localField":   "squad",
+			"foreignField": "_id",
+			"as":           "players",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "matches",
+			"localField":   "players.match",
+			"foreignField": "_id",
+			"as":           "matches",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"team_name":      1,
+			"total_points":   bson.M{"$sum": "$matches.totalPoints"},
+			"earned_points":  bson.M{"$sum": "$matches.earnedPoints"},
+			"benched_points": bson.M{"$sum": "$matches.benchedPoints"},
+		}}},
+	}
+	cursor, err := a.MongoDBClient.Collection("teams").Aggregate(ctx, pipeline)
+	if err != nil {
+		return board, err
+	}
+	if err = cursor.All(ctx, &board.Teams); err != nil {
+		return board, err
+	}
+
+	err = a.MongoDBClient.Collection("fantasy_seasons").FindOne(ctx, bson.M{"_id": auctionID}).Decode(&season)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return board, err
+	}
+	board.Matchday = season.Matchday
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "matchday", Value: 1}}).
+		SetProjection(bson.M{"players": 0})
+	cursor, err = a.MongoDBClient.Collection("matchday_snapshots").Find(ctx, bson.M{"auction_id": auctionID}, findOptions)
+	if err != nil {
+		return board, err
+	}
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		return board, err
+	}
+
+	rankTeams(board.Teams)
+
+	timelines := make(map[primitive.ObjectID][]timelinePoint)
+	prevRanks := make(map[primitive.ObjectID]int)
+	for _, snapshot := range snapshots {
+		standings := make([]leaderboardEntry, 0, len(snapshot.Teams))
+		for _, team := range snapshot.Teams {
+			standings = append(standings, leaderboardEntry{
+				TeamID:        team.TeamID,
+				TeamName:      team.TeamName,
+				TotalPoints:   team.TotalPoints,
+				EarnedPoints:  team.EarnedPoints,
+				BenchedPoints: team.BenchedPoints,
+			})
+		}
+		rankTeams(standings)
+
+		prevRanks = make(map[primitive.ObjectID]int, len(standings))
+		for _, standing := range standings {
+			prevRanks[standing.TeamID] = standing.Rank
+			timelines[standing.TeamID] = append(timelines[standing.TeamID], timelinePoint{
+				Matchday:      snapshot.Matchday,
+				Rank:          standing.Rank,
+				TotalPoints:   standing.TotalPoints,
+				EarnedPoints:  standing.EarnedPoints,
+				BenchedPoints: standing.BenchedPoints,
+			})
+		}
+	}
+
+	for i := range board.Teams {
+		team := &board.Teams[i]
+		team.Timeline = timelines[team.TeamID]
+		if team.Timeline == nil {
+			team.Timeline = []timelinePoint{}
+		}
+		if prevRank, ok := prevRanks[team.TeamID]; ok {
+			team.PrevRank = prevRank
+			team.Movement = prevRank - team.Rank
+		}
+	}
+
+	return board, nil
+}
+
+// rankTeams sorts the teams by total then earned points and assigns their ranks,
+// teams level on both share a rank
+func rankTeams(teams []leaderboardEntry) {
+	sort.SliceStable(teams, func(i, j int) bool {
+		if teams[i].TotalPoints != teams[j].TotalPoints {
+			return teams[i].TotalPoints > teams[j].TotalPoints
+		}
+		if teams[i].EarnedPoints != teams[j].EarnedPoints {
+			return teams[i].EarnedPoints > teams[j].EarnedPoints
+		}
+		return teams[i].TeamName < teams[j].TeamName
+	})
+
+	for i := range teams {
+		teams[i].Rank = i + 1
+		if i > 0 && teams[i].TotalPoints == teams[i-1].TotalPoints && teams[i].EarnedPoints == teams[i-1].EarnedPoints {
+			teams[i].Rank = teams[i-1].Rank
+		}
+	}
+}
+
+// clearLeaderboardCache deletes the cached leaderboard of the auctions
+func (a *API) clearLeaderboardCache(ctx context.Context, auctionIDs ...primitive.ObjectID) error {
+	if len(auctionIDs) == 0 {
+		return nil
+	}
+
+	cacheKeys := make([]string, 0, len(auctionIDs))
+	for _, auctionID := range auctionIDs {
+		cacheKeys = append(cacheKeys, fmt.Sprintf(LeaderboardCacheKey, auctionID.Hex()))
+	}
+	_, err := a.RedisClient.Del(ctx, cacheKeys...).Result()
+	return err
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankTeams(t *testing.T) {
+	type standing struct {
+		name string
+		rank int
+	}
+	entry := func(name string, total, earned int) leaderboardEntry {
+		return leaderboardEntry{TeamName: name, TotalPoints: total, EarnedPoints: earned}
+	}
+
+	tests := []struct {
+		name  string
+		teams []leaderboardEntry
+		want  []standing
+	}{
+		{
+			name:  "empty",
+			teams: []leaderboardEntry{},
+			want:  []standing{},
+		},
+		{
+			name:  "by total points",
+			teams: []leaderboardEntry{entry("Kings", 120, 100), entry("Titans", 300, 250), entry("Royals", 200, 150)},
+			want:  []standing{{"Titans", 1}, {"Royals", 2}, {"Kings", 3}},
+		},
+		{
+			name:  "earned points break a tie",
+			teams: []leaderboardEntry{entry("Kings", 200, 120), entry("Titans", 200, 180)},
+			want:  []standing{{"Titans", 1}, {"Kings", 2}},
+		},
+		{
+			name: "level teams share a rank",
+			teams: []leaderboardEntry{
+				entry("Titans", 200, 150),
+				entry("Kings", 250, 200),
+				entry("Royals", 200, 150),
+				entry("Capitals", 100, 50),
+			},
+			want: []standing{{"Kings", 1}, {"Royals", 2}, {"Titans", 2}, {"Capitals", 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankTeams(tt.teams)
+
+			got := make([]standing, 0, len(tt.teams))
+			for _, team := range tt.teams {
+				got = append(got, standing{team.TeamName, team.Rank})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankTeams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
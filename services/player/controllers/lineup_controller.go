@@ -0,0 +1,483 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// errLineupLocked is returned when the XI deadline passed while saving a pick
+var errLineupLocked = errors.New("lineup deadline passed")
+
+type lineupRequest struct {
+	AuctionID     primitive.ObjectID   `json:"auction_id" binding:"required"`
+	TeamID        primitive.ObjectID   `json:"team_id" binding:"required"`
+	PlayerIDs     []primitive.ObjectID `json:"player_ids"`
+	CaptainID     primitive.ObjectID   `json:"captain_id"`
+	ViceCaptainID primitive.ObjectID   `json:"vice_captain_id"`
+}
+
+// GetLineupController returns the XI of a team in the matchday in play and, to the
+// owners of the team, the XI picked for the next matchday
+func (a *API) GetLineupController(c *gin.Context) {
+	var request lineupRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get lineup request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	// Members of the auction can only read the lineups of its own teams
+	count, err := a.MongoDBClient.Collection("teams").CountDocuments(ctx, bson.M{
+		"_id":        request.TeamID,
+		"auction_id": request.AuctionID,
+	})
+	if err != nil {
+		a.logger.Error("failed to find team", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found in this auction"})
+		return
+	}
+
+	season, err := a.rolloverDueMatchday(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to roll over matchday", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	current, err := a.findLineup(ctx, request.TeamID, season.Matchday)
+	if err != nil {
+		a.logger.Error("failed to fetch current lineup", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	response := gin.H{
+		"message":  "Lineup fetched successfully",
+		"matchday": season.Matchday,
+		"lock_at":  season.LockAt,
+		"current":  current,
+	}
+
+	// The next XI stays hidden from the other teams until it locks
+	err = a.checkTeamOwner(ctx, request.AuctionID, request.TeamID, c.GetString("email"))
+	switch {
+	case err == nil:
+		next, err := a.findLineup(ctx, request.TeamID, season.Matchday+1)
+		if err != nil {
+			a.logger.Error("failed to fetch next lineup", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+		response["next"] = next
+		if next != nil {
+			warnings, err := a.lineupAvailability(ctx, next.Players)
+			if err != nil {
+				a.logger.Error("failed to fetch next lineup players", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+				return
+			}
+			response["warnings"] = warnings
+		}
+	case !errors.Is(err, errTeamNotOwned):
+		a.logger.Error("failed to check team owner", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SaveLineupController picks the playing XI of a team and its captain and vice-captain
+// for the next matchday, until its deadline. Points of the players in the XI count as
+// earned once the matchday starts, the others as benched.
+func (a *API) SaveLineupController(c *gin.Context) {
+	var (
+		request lineupRequest
+		team    models.Team
+		players []models.Player
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind save lineup request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	email := c.GetString("email")
+	if err := a.checkTeamOwner(ctx, request.AuctionID, request.TeamID, email); err != nil {
+		if errors.Is(err, errTeamNotOwned) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the owners of this team can pick its XI"})
+			return
+		}
+		a.logger.Error("failed to check team owner", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpScoreMatches, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	var auction models.Auction
+	if err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction); err != nil {
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !auction.IsIPLAuction {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fantasy points are only tracked for IPL auctions"})
+		return
+	}
+
+	// A passed deadline starts the next matchday, the pick is then for the one after
+	season, err := a.rolloverDueMatchday(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to roll over matchday", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	if err = a.MongoDBClient.Collection("teams").FindOne(ctx, bson.M{"_id": request.TeamID}).Decode(&team); err != nil {
+		a.logger.Error("failed to find team", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	if len(request.PlayerIDs) != PlayingXISize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Playing XI must have %d players", PlayingXISize)})
+		return
+	}
+	inSquad := make(map[primitive.ObjectID]bool, len(team.Squad))
+	for _, playerID := range team.Squad {
+		inSquad[playerID] = true
+	}
+	picked := make(map[primitive.ObjectID]bool, len(request.PlayerIDs))
+	for _, playerID := range request.PlayerIDs {
+		if !inSquad[playerID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Player " + playerID.Hex() + " is not in the squad"})
+			return
+		}
+		if picked[playerID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Player " + playerID.Hex() + " is picked twice"})
+			return
+		}
+		picked[playerID] = true
+	}
+	if !picked[request.CaptainID] || !picked[request.ViceCaptainID] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Captain and vice-captain must be in the playing XI"})
+		return
+	}
+	if request.CaptainID == request.ViceCaptainID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Captain and vice-captain must be different players"})
+		return
+	}
+
+	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, bson.M{"_id": bson.M{"$in": team.Squad}})
+	if err != nil {
+		a.logger.Error("failed to fetch squad players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		a.logger.Error("failed to decode squad players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	if err = checkSquadRules(players, picked); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fixtures, err := a.findFixtures(ctx, request.AuctionID, season.Matchday+1)
+	if err != nil {
+		a.logger.Error("failed to fetch fixtures", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	warnings := append(lineupWarnings(players, picked, fixtures), availabilityWarnings(players, picked)...)
+
+	var selected, benched, squadMatches []primitive.ObjectID
+	captaincies := make(map[primitive.ObjectID]string, 2)
+	for _, player := range players {
+		if player.Match.IsZero() {
+			continue
+		}
+		squadMatches = append(squadMatches, player.Match)
+		if picked[player.Id] {
+			selected = append(selected, player.Match)
+		} else {
+			benched = append(benched, player.Match)
+		}
+		switch player.Id {
+		case request.CaptainID:
+			captaincies[player.Match] = models.CaptaincyCaptain
+		case request.ViceCaptainID:
+			captaincies[player.Match] = models.CaptaincyViceCaptain
+		}
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	now := time.Now()
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		var lineup models.Lineup
+
+		// The deadline is checked again with a write to the season, so a rollover
+		// committing at the same time conflicts with the pick instead of racing it.
+		// The upsert only creates a season not started yet, one that moved on or
+		// locked fails it on the duplicate _id.
+		seasonFilter := bson.M{
+			"_id":      request.AuctionID,
+			"matchday": season.Matchday,
+			"$or":      bson.A{bson.M{"lock_at": nil}, bson.M{"lock_at": bson.M{"$gt": now}}},
+		}
+		seasonUpdate := bson.M{"$set": bson.M{"updated_at": now}}
+		_, err := a.MongoDBClient.Collection("fantasy_seasons").UpdateOne(sessCtx, seasonFilter, seasonUpdate, options.Update().SetUpsert(true))
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errLineupLocked
+			}
+			return nil, err
+		}
+
+		filter := bson.M{"team_id": request.TeamID, "matchday": season.Matchday + 1}
+		update := bson.M{
+			"$set": bson.M{
+				"players":      request.PlayerIDs,
+				"captain":      request.CaptainID,
+				"vice_captain": request.ViceCaptainID,
+				"selected_by":  email,
+				"updated_at":   now,
+			},
+			"$setOnInsert": bson.M{
+				"_id":        primitive.NewObjectID(),
+				"auction_id": request.AuctionID,
+				"created_at": now,
+			},
+		}
+		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+		if err = a.MongoDBClient.Collection("lineups").FindOneAndUpdate(sessCtx, filter, update, opts).Decode(&lineup); err != nil {
+			return nil, err
+		}
+
+		matches := a.MongoDBClient.Collection("matches")
+		if len(selected) > 0 {
+			if _, err = matches.UpdateMany(sessCtx, bson.M{"_id": bson.M{"$in": selected}}, bson.M{"$set": bson.M{"nextX1": true}}); err != nil {
+				return nil, err
+			}
+		}
+		if len(benched) > 0 {
+			if _, err = matches.UpdateMany(sessCtx, bson.M{"_id": bson.M{"$in": benched}}, bson.M{"$set": bson.M{"nextX1": false}}); err != nil {
+				return nil, err
+			}
+		}
+
+		// The captaincy of the previous pick is cleared before the new one is set
+		if len(squadMatches) > 0 {
+			if _, err = matches.UpdateMany(sessCtx, bson.M{"_id": bson.M{"$in": squadMatches}}, bson.M{"$unset": bson.M{"nextCaptaincy": ""}}); err != nil {
+				return nil, err
+			}
+		}
+		for matchID, captaincy := range captaincies {
+			if _, err = matches.UpdateByID(sessCtx, matchID, bson.M{"$set": bson.M{"nextCaptaincy": captaincy}}); err != nil {
+				return nil, err
+			}
+		}
+		return lineup, nil
+	})
+	if err != nil {
+		if errors.Is(err, errLineupLocked) {
+			c.JSON(http.StatusConflict, gin.H{"error": "The XI deadline has passed"})
+			return
+		}
+		a.logger.Error("failed to save lineup", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save lineup"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Playing XI saved successfully",
+		"lineup":   result,
+		"lock_at":  season.LockAt,
+		"warnings": warnings,
+	})
+}
+
+// SetLineupDeadlineController sets when the XI of the next matchday locks
+func (a *API) SetLineupDeadlineController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			LockAt    time.Time          `json:"lock_at" binding:"required"`
+		}
+		season models.FantasySeason
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind lineup deadline request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if !request.LockAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Deadline must be in the future"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpScoreMatches, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	// A deadline already passed starts its matchday before the next one is set
+	if _, err := a.rolloverDueMatchday(ctx, request.AuctionID); err != nil {
+		a.logger.Error("failed to roll over matchday", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	update := bson.M{
+		"$set":         bson.M{"lock_at": request.LockAt.UTC(), "updated_at": time.Now()},
+		"$setOnInsert": bson.M{"matchday": 0},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := a.MongoDBClient.Collection("fantasy_seasons").FindOneAndUpdate(ctx, bson.M{"_id": request.AuctionID}, update, opts).Decode(&season)
+	if err != nil {
+		a.logger.Error("failed to set lineup deadline", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set deadline"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Deadline set successfully",
+		"season":  season,
+	})
+}
+
+// checkSquadRules verifies the picked players of the squad make a valid XI
+func checkSquadRules(squad []models.Player, picked map[primitive.ObjectID]bool) error {
+	overseas, keepers := 0, 0
+	for _, player := range squad {
+		if !picked[player.Id] {
+			continue
+		}
+		if isOverseas(player.Country) {
+			overseas++
+		}
+		if player.Role == models.RoleWicketKeeper {
+			keepers++
+		}
+	}
+
+	if overseas > MaxOverseasInXI {
+		return fmt.Errorf("playing XI can have at most %d overseas players", MaxOverseasInXI)
+	}
+	if keepers == 0 {
+		return errors.New("playing XI needs at least one wicket-keeper")
+	}
+	return nil
+}
+
+// lineupWarnings lists the picked players whose IPL team has no fixture in the
+// matchday, nothing is reported when the matchday has no fixtures at all
+func lineupWarnings(squad []models.Player, picked map[primitive.ObjectID]bool, fixtures []models.Fixture) []string {
+	warnings := []string{}
+	if len(fixtures) == 0 {
+		return warnings
+	}
+
+	for _, player := range squad {
+		if !picked[player.Id] {
+			continue
+		}
+		playing := false
+		for _, fixture := range fixtures {
+			if fixture.Involves(player.IPLTeam) {
+				playing = true
+				break
+			}
+		}
+		if !playing {
+			warnings = append(warnings, fmt.Sprintf("%s has no match in matchday %d", player.PlayerName, fixtures[0].Matchday))
+		}
+	}
+	return warnings
+}
+
+// isOverseas reports whether a player from the country counts as overseas
+func isOverseas(country string) bool {
+	return country != "" && !strings.EqualFold(country, HomeCountry)
+}
+
+// findLineup returns the XI of the team for the matchday, nil when none was picked
+func (a *API) findLineup(ctx context.Context, teamID primitive.ObjectID, matchday int) (*models.Lineup, error) {
+	var lineup models.Lineup
+
+	err := a.MongoDBClient.Collection("lineups").FindOne(ctx, bson.M{"team_id": teamID, "matchday": matchday}).Decode(&lineup)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lineup, nil
+}
+
+// lineupAvailability returns the availability warnings of the players of an XI
+func (a *API) lineupAvailability(ctx context.Context, playerIDs []primitive.ObjectID) ([]string, error) {
+	var players []models.Player
+
+	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, bson.M{"_id": bson.M{"$in": playerIDs}})
+	if err != nil {
+		return nil, err
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+
+	picked := make(map[primitive.ObjectID]bool, len(playerIDs))
+	for _, playerID := range playerIDs {
+		picked[playerID] = true
+	}
+	return availabilityWarnings(players, picked), nil
+}
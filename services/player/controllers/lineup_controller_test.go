@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"auction-web/pkg/models"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCheckSquadRules(t *testing.T) {
+	player := func(role, country string) models.Player {
+		return models.Player{Id: primitive.NewObjectID(), Role: role, Country: country}
+	}
+	keeper := player(models.RoleWicketKeeper, "India")
+	overseasKeeper := player(models.RoleWicketKeeper, "South Africa")
+	overseas := []models.Player{
+		player(models.RoleBatter, "Australia"),
+		player(models.RoleBowler, "England"),
+		player(models.RoleAllRounder, "West Indies"),
+		player(models.RoleBowler, "afghanistan"),
+	}
+	home := player(models.RoleBatter, "india")
+	unknown := player(models.RoleBowler, "")
+
+	squad := append([]models.Player{keeper, overseasKeeper, home, unknown}, overseas...)
+	pick := func(players ...models.Player) map[primitive.ObjectID]bool {
+		picked := make(map[primitive.ObjectID]bool, len(players))
+		for _, p := range players {
+			picked[p.Id] = true
+		}
+		return picked
+	}
+
+	tests := []struct {
+		name    string
+		picked  map[primitive.ObjectID]bool
+		wantErr string
+	}{
+		{
+			name:   "keeper and four overseas",
+			picked: pick(append([]models.Player{keeper, home, unknown}, overseas...)...),
+		},
+		{
+			name:    "five overseas",
+			picked:  pick(append([]models.Player{overseasKeeper, home}, overseas...)...),
+			wantErr: "playing XI can have at most 4 overseas players",
+		},
+		{
+			name:    "no wicket-keeper",
+			picked:  pick(home, unknown, overseas[0]),
+			wantErr: "playing XI needs at least one wicket-keeper",
+		},
+		{
+			name:   "benched players do not count",
+			picked: pick(keeper, home),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSquadRules(squad, tt.picked)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkSquadRules() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("checkSquadRules() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
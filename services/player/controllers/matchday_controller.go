@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// RolloverMatchdayController closes the matchday in play right away, without
+// waiting for the XI deadline
+func (a *API) RolloverMatchdayController(c *gin.Context) {
+	var request struct {
+		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind rollover request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpScoreMatches, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	season, err := a.rolloverMatchday(ctx, request.AuctionID, false)
+	if err != nil {
+		a.logger.Error("failed to roll over matchday", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to roll over matchday"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Matchday closed successfully",
+		"season":  season,
+	})
+}
+
+// MatchdayHistoryController returns the snapshots of every closed matchday of the auction
+func (a *API) MatchdayHistoryController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+		snapshots = []models.MatchdaySnapshot{}
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind matchday history request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "matchday", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("matchday_snapshots").Find(ctx, bson.M{"auction_id": request.AuctionID}, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch matchday snapshots", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		a.logger.Error("failed to decode matchday snapshots", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Matchday history fetched successfully",
+		"snapshots": snapshots,
+	})
+}
+
+// RunMatchdayRollover periodically closes the matchdays whose XI deadline has passed
+func (a *API) RunMatchdayRollover(ctx context.Context) {
+	ticker := time.NewTicker(RolloverInterval)
+	defer ticker.Stop()
+
+	for {
+		a.rolloverDueMatchdays(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rolloverDueMatchdays runs a single rollover pass
+func (a *API) rolloverDueMatchdays(parent context.Context) {
+	var seasons []models.FantasySeason
+
+	ctx, cancel := context.WithTimeout(parent, constants.DBTimeout)
+	defer cancel()
+
+	cursor, err := a.MongoDBClient.Collection("fantasy_seasons").Find(ctx, bson.M{"lock_at": bson.M{"$lte": time.Now()}})
+	if err != nil {
+		a.logger.Error("failed to fetch due matchdays", zap.Error(err))
+		return
+	}
+	if err = cursor.All(ctx, &seasons); err != nil {
+		a.logger.Error("failed to decode due matchdays", zap.Error(err))
+		return
+	}
+
+	for _, season := range seasons {
+		jobCtx, jobCancel := context.WithTimeout(parent, constants.DBTimeout)
+		if _, err = a.rolloverMatchday(jobCtx, season.AuctionID, true); err != nil {
+			a.logger.Error("failed to roll over matchday", zap.Error(err), zap.Any("auction_id", season.AuctionID))
+		}
+		jobCancel()
+	}
+}
+
+// rolloverDueMatchday closes the matchday in play if its XI deadline has passed and
+// returns the season as it stands
+func (a *API) rolloverDueMatchday(ctx context.Context, auctionID primitive.ObjectID) (models.FantasySeason, error) {
+	return a.rolloverMatchday(ctx, auctionID, true)
+}
+
+// rolloverMatchday closes the matchday in play, only once its deadline passed when
+// due is set. The points of every match document are snapshotted into the Prev
+// fields and the matchday history, and the XI flags shift forward: the current XI
+// becomes the previous one and the picked XI, with its captaincy, the current one.
+// The deadline of the following matchday comes from the fixtures when there are any.
+func (a *API) rolloverMatchday(ctx context.Context, auctionID primitive.ObjectID, due bool) (models.FantasySeason, error) {
+	rolled := false
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		return models.FantasySeason{}, err
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		season := models.FantasySeason{AuctionID: auctionID}
+		seasons := a.MongoDBClient.Collection("fantasy_seasons")
+
+		err := seasons.FindOne(sessCtx, bson.M{"_id": auctionID}).Decode(&season)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		now := time.Now()
+		rolled = false
+		if due && !season.Locked(now) {
+			return season, nil
+		}
+
+		snapshot, matchIDs, err := a.buildMatchdaySnapshot(sessCtx, auctionID, season.Matchday)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.ClosedAt = now
+		if _, err = a.MongoDBClient.Collection("matchday_snapshots").InsertOne(sessCtx, snapshot); err != nil {
+			return nil, err
+		}
+
+		if len(matchIDs) > 0 {
+			_, err = a.MongoDBClient.Collection("matches").UpdateMany(sessCtx,
+				bson.M{"_id": bson.M{"$in": matchIDs}},
+				mongo.Pipeline{{{Key: "$set", Value: bson.M{
+					"prevTotalPoints":   "$totalPoints",
+					"prevEarnedPoints":  "$earnedPoints",
+					"prevBenchedPoints": "$benchedPoints",
+					"prevX1":            "$currentX1",
+					"currentX1":         "$nextX1",
+					"currentCaptaincy":  bson.M{"$ifNull": bson.A{"$nextCaptaincy", ""}},
+				}}}})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rolled = true
+		season.Matchday++
+		if season.LockAt, err = a.fixtureLockAt(sessCtx, auctionID, season.Matchday+1); err != nil {
+			return nil, err
+		}
+		season.LastRolloverAt = &now
+		season.UpdatedAt = now
+		if _, err = seasons.ReplaceOne(sessCtx, bson.M{"_id": auctionID}, season, options.Replace().SetUpsert(true)); err != nil {
+			return nil, err
+		}
+		return season, nil
+	})
+	if err != nil {
+		return models.FantasySeason{}, err
+	}
+
+	season := result.(models.FantasySeason)
+	if rolled {
+		if err = a.clearLeaderboardCache(ctx, auctionID); err != nil {
+			a.logger.Warn("failed to clear leaderboard cache after rollover", zap.Error(err))
+		}
+	}
+	return season, nil
+}
+
+// buildMatchdaySnapshot collects the points of every player of the auction and of
+// every team over its squad, along with the match documents of those players
+func (a *API) buildMatchdaySnapshot(ctx context.Context, auctionID primitive.ObjectID, matchday int) (models.MatchdaySnapshot, []primitive.ObjectID, error) {
+	var (
+		players []models.Player
+		matches []models.Match
+		teams   []models.Team
+	)
+
+	snapshot := models.MatchdaySnapshot{
+		ID:        primitive.NewObjectID(),
+		AuctionID: auctionID,
+		Matchday:  matchday,
+		Teams:     []models.TeamSnapshot{},
+		Players:   []models.PlayerSnapshot{},
+	}
+
+	playerFilter := bson.M{"auction_id": auctionID, "match": bson.M{"$exists": true, "$ne": primitive.NilObjectID}}
+	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, playerFilter)
+	if err != nil {
+		return snapshot, nil, err
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		return snapshot, nil, err
+	}
+
+	matchIDs := make([]primitive.ObjectID, 0, len(players))
+	for _, player := range players {
+		matchIDs = append(matchIDs, player.Match)
+	}
+	if len(matchIDs) == 0 {
+		return snapshot, nil, nil
+	}
+
+	cursor, err = a.MongoDBClient.Collection("matches").Find(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+	if err != nil {
+		return snapshot, nil, err
+	}
+	if err = cursor.All(ctx, &matches); err != nil {
+		return snapshot, nil, err
+	}
+
+	cursor, err = a.MongoDBClient.Collection("teams").Find(ctx, bson.M{"auction_id": auctionID})
+	if err != nil {
+		return snapshot, nil, err
+	}
+	if err = cursor.All(ctx, &teams); err != nil {
+		return snapshot, nil, err
+	}
+
+	teamOf := make(map[primitive.ObjectID]primitive.ObjectID)
+	for _, team := range teams {
+		for _, playerID := range team.Squad {
+			teamOf[playerID] = team.ID
+		}
+	}
+	matchByID := make(map[primitive.ObjectID]models.Match, len(matches))
+	for _, match := range matches {
+		matchByID[match.Id] = match
+	}
+
+	teamPoints := make(map[primitive.ObjectID]*models.TeamSnapshot, len(teams))
+	for _, team := range teams {
+		snapshot.Teams = append(snapshot.Teams, models.TeamSnapshot{TeamID: team.ID, TeamName: team.TeamName})
+	}
+	for i := range snapshot.Teams {
+		teamPoints[snapshot.Teams[i].TeamID] = &snapshot.Teams[i]
+	}
+
+	for _, player := range players {
+		match, ok := matchByID[player.Match]
+		if !ok {
+			continue
+		}
+		snapshot.Players = append(snapshot.Players, models.PlayerSnapshot{
+			PlayerID:      player.Id,
+			TeamID:        teamOf[player.Id],
+			TotalPoints:   match.TotalPoints,
+			EarnedPoints:  match.EarnedPoints,
+			BenchedPoints: match.BenchedPoints,
+			InXI:          match.CurrentX1,
+		})
+		if team, ok := teamPoints[teamOf[player.Id]]; ok {
+			team.TotalPoints += match.TotalPoints
+			team.EarnedPoints += match.EarnedPoints
+			team.BenchedPoints += match.BenchedPoints
+		}
+	}
+
+	return snapshot, matchIDs, nil
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type catalogPick struct {
+	CatalogID primitive.ObjectID `json:"catalog_id" binding:"required"`
+	BasePrice float64            `json:"base_price"`
+	PrevTeam  string             `json:"prev_team"`
+}
+
+// PopulateAuctionController creates auction players from picked catalog entries
+func (a *API) PopulateAuctionController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Picks     []catalogPick      `json:"picks" binding:"required,dive"`
+		}
+		catalog []models.CatalogPlayer
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind populate auction request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManagePlayers, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	isIPLAuction := c.Query("isIPLAuction")
+	if isIPLAuction == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request query params"})
+		return
+	}
+
+	catalogIDs := make([]primitive.ObjectID, 0, len(request.Picks))
+	for _, pick := range request.Picks {
+		catalogIDs = append(catalogIDs, pick.CatalogID)
+	}
+
+	cursor, err := a.MongoDBClient.Collection("player_catalog").Find(ctx, bson.M{"_id": bson.M{"$in": catalogIDs}})
+	if err != nil {
+		a.logger.Error("failed to fetch catalog players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &catalog); err != nil {
+		a.logger.Error("failed to decode catalog players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	catalogByID := make(map[primitive.ObjectID]models.CatalogPlayer, len(catalog))
+	for _, entry := range catalog {
+		catalogByID[entry.ID] = entry
+	}
+
+	// Skip entries already present in the auction
+	linked, err := a.MongoDBClient.Collection("players").Distinct(ctx, "catalog_id", bson.M{
+		"auction_id": request.AuctionID,
+		"catalog_id": bson.M{"$in": catalogIDs},
+	})
+	if err != nil {
+		a.logger.Error("failed to fetch linked catalog players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	alreadyLinked := make(map[primitive.ObjectID]bool, len(linked))
+	for _, id := range linked {
+		if oid, ok := id.(primitive.ObjectID); ok {
+			alreadyLinked[oid] = true
+		}
+	}
+
+	nextNumber, err := a.nextPlayerNumber(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to fetch last player number", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	var (
+		players []models.Player
+		skipped = []primitive.ObjectID{}
+	)
+	for _, pick := range request.Picks {
+		entry, ok := catalogByID[pick.CatalogID]
+		if !ok || alreadyLinked[pick.CatalogID] {
+			skipped = append(skipped, pick.CatalogID)
+			continue
+		}
+		alreadyLinked[pick.CatalogID] = true
+
+		players = append(players, models.Player{
+			AuctionId:    request.AuctionID,
+			CatalogId:    entry.ID,
+			PlayerNumber: nextNumber,
+			PlayerName:   entry.PlayerName,
+			Country:      entry.Country,
+			Role:         entry.Role,
+			IPLTeam:      entry.IPLTeam,
+			PrevTeam:     pick.PrevTeam,
+			BasePrice:    pick.BasePrice,
+		})
+		nextNumber++
+	}
+
+	if len(players) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No new catalog players to add", "skipped": skipped})
+		return
+	}
+
+	if err = a.insertPlayers(ctx, request.AuctionID, players, isIPLAuction == "true", "players:populate", c.GetHeader(IdempotencyHeader)); err != nil {
+		if errors.Is(err, errDuplicateRequest) {
+			c.JSON(http.StatusOK, gin.H{"message": "Players already added for this request"})
+			return
+		}
+		a.logger.Error("failed to populate auction from catalog", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save players"})
+		return
+	}
+
+	if err = a.clearPlayerCache(ctx, request.AuctionID); err != nil {
+		a.logger.Warn("failed to clear player cache after populate", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Auction populated successfully",
+		"players": len(players),
+		"skipped": skipped,
+	})
+}
+
+// nextPlayerNumber returns the number following the highest player number of an auction
+func (a *API) nextPlayerNumber(ctx context.Context, auctionID primitive.ObjectID) (int, error) {
+	var last models.Player
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "player_number", Value: -1}})
+	err := a.MongoDBClient.Collection("players").FindOne(ctx, bson.M{"auction_id": auctionID}, opts).Decode(&last)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 1, nil
+		}
+		return 0, err
+	}
+	return last.PlayerNumber + 1, nil
+}
@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// priceHistoryEntry is one auction appearance of a catalog player. Appearances in
+// private auctions of which the caller is not a member only keep the prices and date.
+type priceHistoryEntry struct {
+	PlayerID     *primitive.ObjectID `bson:"player_id" json:"player_id,omitempty"`
+	AuctionID    *primitive.ObjectID `bson:"auction_id" json:"auction_id,omitempty"`
+	AuctionName  string              `bson:"auction_name" json:"auction_name,omitempty"`
+	AuctionDate  time.Time           `bson:"auction_date" json:"auction_date"`
+	PlayerNumber int                 `bson:"player_number" json:"player_number,omitempty"`
+	Hammer       string              `bson:"hammer" json:"hammer"`
+	BasePrice    float64             `bson:"base_price" json:"base_price"`
+	SellingPrice float64             `bson:"selling_price" json:"selling_price"`
+	CurrentTeam  string              `bson:"current_team" json:"current_team,omitempty"`
+	Private      bool                `bson:"-" json:"private,omitempty"`
+	IsPublic     bool                `bson:"is_public" json:"-"`
+	Members      []string            `bson:"members" json:"-"`
+}
+
+// sold reports whether the player was bought by a team in that auction
+func (e priceHistoryEntry) sold() bool {
+	return e.CurrentTeam != "" && e.SellingPrice > 0
+}
+
+// playerValuation is the estimated price of a player derived from its history
+type playerValuation struct {
+	Estimate     float64 `json:"estimate"`
+	Basis        string  `json:"basis"` // history | base_price
+	Sales        int     `json:"sales"`
+	AveragePrice float64 `json:"average_price,omitempty"`
+	LastPrice    float64 `json:"last_price,omitempty"`
+	PointsFactor float64 `json:"points_factor"`
+}
+
+// PlayerDetailController returns an auction player with its price history across
+// previous auctions and a valuation estimate
+func (a *API) PlayerDetailController(c *gin.Context) {
+	var (
+		request struct {
+			PlayerID primitive.ObjectID `json:"player_id" binding:"required"`
+		}
+		player  models.Player
+		history = []priceHistoryEntry{}
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind player detail request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("players").FindOne(ctx, bson.M{"_id": request.PlayerID}).Decode(&player)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		a.logger.Error("failed to find player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find player"})
+		return
+	}
+
+	// Only players linked to the catalog can be traced across auctions
+	if !player.CatalogId.IsZero() {
+		history, err = a.priceHistory(ctx, player.CatalogId, player.Id)
+		if err != nil {
+			a.logger.Error("failed to fetch price history", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+			return
+		}
+	}
+
+	averagePoints, err := a.averageFantasyPoints(ctx, player.AuctionId)
+	if err != nil {
+		a.logger.Error("failed to fetch average fantasy points", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	// The valuation uses every sale, the listed history only what the caller may see
+	valuation := valuePlayer(player, history, averagePoints)
+	history, err = a.redactPriceHistory(ctx, history, c.GetString("email"))
+	if err != nil {
+		a.logger.Error("failed to check price history visibility", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Player fetched successfully",
+		"player":        player,
+		"price_history": history,
+		"valuation":     valuation,
+	})
+}
+
+// priceHistory returns every appearance of the catalog player in auctions that
+// are not deleted, latest auction first, leaving out the excluded player
+func (a *API) priceHistory(ctx context.Context, catalogID, exclude primitive.ObjectID) ([]priceHistoryEntry, error) {
+	history := []priceHistoryEntry{}
+
+	match := bson.M{"catalog_id": catalogID}
+	if !exclude.IsZero() {
+		match["_id"] = bson.M{"$ne": exclude}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "auctions",
+			"localField":   "auction_id",
+			"foreignField": "_id",
+			"as":           "auction",
+		}}},
+		{{Key: "$unwind", Value: "$auction"}},
+		{{Key: "$match", Value: bson.M{"auction.deleted_at": bson.M{"$exists": false}}}},
+		{{Key: "$project", Value: bson.M{
+			"player_id":     "$_id",
+			"auction_id":    1,
+			"auction_name":  "$auction.auction_name",
+			"auction_date":  "$auction.auction_date",
+			"player_number": 1,
+			"hammer":        1,
+			"base_price":    1,
+			"selling_price": 1,
+			"current_team":  1,
+			"is_public":     "$auction.is_public",
+			"members": bson.M{"$concatArrays": bson.A{
+				bson.A{"$auction.created_by"},
+				bson.M{"$ifNull": bson.A{"$auction.co_admins", bson.A{}}},
+				bson.M{"$ifNull": bson.A{"$auction.joined_by", bson.A{}}},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "auction_date", Value: -1}, {Key: "player_id", Value: -1}}}},
+	}
+
+	cursor, err := a.MongoDBClient.Collection("players").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// redactPriceHistory keeps the auction and team of the entries of public auctions and of
+// auctions the user is a member or team owner of, the other entries only keep their prices
+func (a *API) redactPriceHistory(ctx context.Context, history []priceHistoryEntry, email string) ([]priceHistoryEntry, error) {
+	var hidden []primitive.ObjectID
+	for _, entry := range history {
+		if !entry.IsPublic && !slices.Contains(entry.Members, email) {
+			hidden = append(hidden, *entry.AuctionID)
+		}
+	}
+	if len(hidden) == 0 {
+		return history, nil
+	}
+
+	owned, err := a.MongoDBClient.Collection("teams").Distinct(ctx, "auction_id", bson.M{
+		"auction_id":  bson.M{"$in": hidden},
+		"team_owners": email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := make([]priceHistoryEntry, 0, len(history))
+	for _, entry := range history {
+		if entry.IsPublic || slices.Contains(entry.Members, email) || slices.Contains(owned, any(*entry.AuctionID)) {
+			redacted = append(redacted, entry)
+			continue
+		}
+		redacted = append(redacted, priceHistoryEntry{
+			AuctionDate:  entry.AuctionDate,
+			Hammer:       entry.Hammer,
+			BasePrice:    entry.BasePrice,
+			SellingPrice: entry.SellingPrice,
+			Private:      true,
+		})
+	}
+	return redacted, nil
+}
+
+// averageFantasyPoints returns the mean previous fantasy points of the players of
+// the auction that have any, zero when none do
+func (a *API) averageFantasyPoints(ctx context.Context, auctionID primitive.ObjectID) (float64, error) {
+	var result []struct {
+		Average float64 `bson:"average"`
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"auction_id": auctionID, "prev_fantasy_points": bson.M{"$gt": 0}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "average": bson.M{"$avg": "$prev_fantasy_points"}}}},
+	}
+
+	cursor, err := a.MongoDBClient.Collection("players").Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Average, nil
+}
+
+// valuePlayer estimates the price of the player. Past sales are averaged with more
+// weight on recent auctions, falling back to the base price without any sale, and
+// the result is scaled by how the previous fantasy points of the player compare
+// with the auction average. The estimate never drops below the base price.
+func valuePlayer(player models.Player, history []priceHistoryEntry, averagePoints float64) playerValuation {
+	valuation := playerValuation{Basis: "base_price", PointsFactor: 1}
+
+	// history is latest first, so earlier entries get the larger weights
+	var weighted, weights float64
+	for i, entry := range history {
+		if !entry.sold() {
+			continue
+		}
+		if valuation.Sales == 0 {
+			valuation.LastPrice = entry.SellingPrice
+		}
+		valuation.Sales++
+		valuation.AveragePrice += entry.SellingPrice
+
+		weight := float64(len(history) - i)
+		weighted += weight * entry.SellingPrice
+		weights += weight
+	}
+
+	estimate := player.BasePrice
+	if valuation.Sales > 0 {
+		valuation.AveragePrice /= float64(valuation.Sales)
+		valuation.Basis = "history"
+		estimate = weighted / weights
+	}
+
+	if averagePoints > 0 && player.PrevFantasyPoints > 0 {
+		factor := float64(player.PrevFantasyPoints) / averagePoints
+		valuation.PointsFactor = math.Min(math.Max(factor, MinPointsFactor), MaxPointsFactor)
+	}
+	estimate *= valuation.PointsFactor
+
+	valuation.Estimate = math.Round(math.Max(estimate, player.BasePrice)*100) / 100
+	return valuation
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"auction-web/pkg/models"
+	"testing"
+)
+
+func TestValuePlayer(t *testing.T) {
+	sold := func(price float64) priceHistoryEntry {
+		return priceHistoryEntry{Hammer: models.HammerSold, CurrentTeam: "Titans", SellingPrice: price}
+	}
+	unsold := priceHistoryEntry{Hammer: models.HammerUnsold}
+
+	tests := []struct {
+		name          string
+		player        models.Player
+		history       []priceHistoryEntry
+		averagePoints float64
+		want          playerValuation
+	}{
+		{
+			name:   "no history falls back to base price",
+			player: models.Player{BasePrice: 100},
+			want:   playerValuation{Estimate: 100, Basis: "base_price", PointsFactor: 1},
+		},
+		{
+			name:    "recent sales weigh more",
+			player:  models.Player{BasePrice: 100},
+			history: []priceHistoryEntry{sold(300), unsold, sold(200)},
+			want: playerValuation{
+				Estimate:     275,
+				Basis:        "history",
+				Sales:        2,
+				AveragePrice: 250,
+				LastPrice:    300,
+				PointsFactor: 1,
+			},
+		},
+		{
+			name:    "estimate is rounded to cents",
+			player:  models.Player{BasePrice: 50},
+			history: []priceHistoryEntry{sold(100), sold(200)},
+			want: playerValuation{
+				Estimate:     133.33,
+				Basis:        "history",
+				Sales:        2,
+				AveragePrice: 150,
+				LastPrice:    100,
+				PointsFactor: 1,
+			},
+		},
+		{
+			name:          "points factor is capped",
+			player:        models.Player{BasePrice: 100, PrevFantasyPoints: 400},
+			averagePoints: 100,
+			want:          playerValuation{Estimate: 125, Basis: "base_price", PointsFactor: MaxPointsFactor},
+		},
+		{
+			name:          "points factor is floored",
+			player:        models.Player{BasePrice: 100, PrevFantasyPoints: 10},
+			history:       []priceHistoryEntry{sold(400)},
+			averagePoints: 100,
+			want: playerValuation{
+				Estimate:     300,
+				Basis:        "history",
+				Sales:        1,
+				AveragePrice: 400,
+				LastPrice:    400,
+				PointsFactor: MinPointsFactor,
+			},
+		},
+		{
+			name:          "estimate never drops below base price",
+			player:        models.Player{BasePrice: 100, PrevFantasyPoints: 50},
+			averagePoints: 100,
+			want:          playerValuation{Estimate: 100, Basis: "base_price", PointsFactor: MinPointsFactor},
+		},
+		{
+			name:          "unsold appearances are ignored",
+			player:        models.Player{BasePrice: 80, PrevFantasyPoints: 120},
+			history:       []priceHistoryEntry{unsold, unsold},
+			averagePoints: 100,
+			want:          playerValuation{Estimate: 96, Basis: "base_price", PointsFactor: 1.2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := valuePlayer(tt.player, tt.history, tt.averagePoints); got != tt.want {
+				t.Errorf("valuePlayer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,22 +2,31 @@ package controllers
 
 import (
 	"auction-web/internal/constants"
+	"auction-web/pkg/middlewares"
 	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
+var (
+	// errDuplicateRequest is returned when an idempotency key was already used
+	errDuplicateRequest = errors.New("request already processed")
+	// errForeignPlayer is returned when a batch holds players of another auction
+	errForeignPlayer = errors.New("player belongs to another auction")
+)
+
 func (a *API) SavePlayerController(c *gin.Context) {
-	var (
-		players []models.Player
-		match   models.Match
-	)
+	var players []models.Player
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
 	defer cancel()
@@ -34,65 +43,136 @@ func (a *API) SavePlayerController(c *gin.Context) {
 		return
 	}
 
+	if len(players) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No players to save"})
+		return
+	}
+
+	auctionID := middlewares.AuthorizedAuction(c)
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpManagePlayers, auctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", auctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	// Retries with the same key must not create duplicate players
+	idempotencyKey := c.GetHeader(IdempotencyHeader)
+
+	err := a.insertPlayers(ctx, auctionID, players, isIPLAuction == "true", "players:save", idempotencyKey)
+	if err != nil {
+		if errors.Is(err, errForeignPlayer) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "All players must belong to the same auction"})
+			return
+		}
+		if errors.Is(err, errDuplicateRequest) {
+			c.JSON(http.StatusOK, gin.H{
+				"message": "Players already saved for this request",
+				"players": 0,
+			})
+			return
+		}
+		a.logger.Error("failed to save players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save players"})
+		return
+	}
+
+	if err = a.clearPlayerCache(ctx, auctionID); err != nil {
+		a.logger.Warn("failed to clear player cache after save", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Players saved successfully",
+		"players": len(players),
+	})
+}
+
+// insertPlayers inserts the players of the auction, and their match documents for
+// IPL auctions, in one transaction so either the whole batch is saved or nothing is
+func (a *API) insertPlayers(ctx context.Context, auctionID primitive.ObjectID, players []models.Player, isIPLAuction bool, scope, idempotencyKey string) error {
 	for _, player := range players {
-		if isIPLAuction == "true" {
-			match = models.Match{
-				Id:                primitive.NewObjectID(),
-				Matches:           []int{},
-				PrevX1:            false,
-				CurrentX1:         false,
-				NextX1:            false,
-				EarnedPoints:      0,
-				BenchedPoints:     0,
-				TotalPoints:       0,
-				PrevTotalPoints:   0,
-				PrevEarnedPoints:  0,
-				PrevBenchedPoints: 0,
-			}
+		if player.AuctionId != auctionID {
+			return errForeignPlayer
+		}
+	}
 
-			// Save match to database
-			_, err := a.MongoDBClient.Collection("matches").InsertOne(ctx, match)
-			if err != nil {
-				a.logger.Error("failed to create match document for player", zap.Error(err))
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create match record"})
-				return
-			}
+	var (
+		now        = time.Now()
+		playerDocs = make([]any, 0, len(players))
+		matchDocs  = make([]any, 0, len(players))
+	)
 
-			if player.PrevFantasyPoints == 0 {
-				player.PrevFantasyPoints = 0
+	for i := range players {
+		player := &players[i]
+		player.Id = primitive.NewObjectID()
+		player.Match = primitive.NilObjectID
+		if isIPLAuction {
+			match := models.Match{
+				Id:        primitive.NewObjectID(),
+				AuctionID: player.AuctionId,
+				Matches:   []int{},
 			}
+			matchDocs = append(matchDocs, match)
+			player.Match = match.Id
 		}
-
-		player.Match = match.Id
-		if len(player.PrevTeam) == 0 {
-			player.PrevTeam = ""
+		if player.Bids == nil {
+			player.Bids = []models.Bids{}
 		}
 		player.CurrentTeam = ""
 		player.Hammer = "upcoming"
 		player.SellingPrice = float64(0)
-		player.CreatedAt = time.Now()
-		player.UpdatedAt = time.Now()
-
-		// Save player to database
-		_, err := a.MongoDBClient.Collection("players").InsertOne(ctx, player)
-		if err != nil {
-			a.logger.Error("failed to save player", zap.Error(err))
-			// Try to cleanup the match document if player creation fails
-			if isIPLAuction == "true" {
-				_, err = a.MongoDBClient.Collection("matches").DeleteOne(ctx, bson.M{"_id": match.Id})
-				if err != nil {
-					a.logger.Error("failed to delete match document for player", zap.Error(err), zap.Any("match", match.Id))
+		player.CreatedAt = now
+		player.UpdatedAt = now
+		playerDocs = append(playerDocs, *player)
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		if idempotencyKey != "" {
+			// Keys are only unique within the endpoint and auction they were sent to
+			keyDoc := bson.M{
+				"_id": bson.D{
+					{Key: "scope", Value: scope},
+					{Key: "auction_id", Value: auctionID},
+					{Key: "key", Value: idempotencyKey},
+				},
+				"players":    len(players),
+				"created_at": now,
+			}
+			if _, err := a.MongoDBClient.Collection("idempotency_keys").InsertOne(sessCtx, keyDoc); err != nil {
+				if mongo.IsDuplicateKeyError(err) {
+					return nil, errDuplicateRequest
 				}
+				return nil, err
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save player"})
-			return
 		}
 
-		match = models.Match{}
-	}
+		if len(matchDocs) > 0 {
+			if _, err := a.MongoDBClient.Collection("matches").InsertMany(sessCtx, matchDocs); err != nil {
+				return nil, err
+			}
+		}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Players saved successfully",
-		"players": len(players),
+		if _, err := a.MongoDBClient.Collection("players").InsertMany(sessCtx, playerDocs); err != nil {
+			return nil, err
+		}
+		return nil, nil
 	})
+
+	return err
+}
+
+// ensureIdempotencyIndex expires the idempotency keys once a retry can no longer arrive
+func (a *API) ensureIdempotencyIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(IdempotencyKeyTTL.Seconds())),
+	}
+	_, err := a.MongoDBClient.Collection("idempotency_keys").Indexes().CreateOne(ctx, index)
+	return err
 }
@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type scorecardRequest struct {
+	AuctionID    primitive.ObjectID   `json:"auction_id" binding:"required"`
+	MatchNumber  int                  `json:"match_number" binding:"required"`
+	Performances []models.Performance `json:"performances" binding:"required,min=1,dive"`
+}
+
+// IngestScorecardController stores the scorecard of an IPL match and records the
+// fantasy points of every player in their match documents. Sending the scorecard
+// of a match again replaces its points.
+func (a *API) IngestScorecardController(c *gin.Context) {
+	var (
+		request scorecardRequest
+		auction models.Auction
+		players []models.Player
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind scorecard request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.MatchNumber <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match number"})
+		return
+	}
+
+	playerIDs := make([]primitive.ObjectID, 0, len(request.Performances))
+	seen := make(map[primitive.ObjectID]bool, len(request.Performances))
+	for _, performance := range request.Performances {
+		if !performance.Valid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid performance of player " + performance.PlayerID.Hex()})
+			return
+		}
+		if seen[performance.PlayerID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Duplicate performance of player " + performance.PlayerID.Hex()})
+			return
+		}
+		seen[performance.PlayerID] = true
+		playerIDs = append(playerIDs, performance.PlayerID)
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpScoreMatches, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+	if err != nil {
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !auction.IsIPLAuction {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Fantasy points are only tracked for IPL auctions"})
+		return
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": playerIDs}, "auction_id": request.AuctionID}
+	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, filter)
+	if err != nil {
+		a.logger.Error("failed to fetch scorecard players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		a.logger.Error("failed to decode scorecard players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+	if len(players) != len(playerIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scorecard has players outside this auction"})
+		return
+	}
+
+	matchOf := make(map[primitive.ObjectID]primitive.ObjectID, len(players))
+	for _, player := range players {
+		if player.Match.IsZero() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Player " + player.PlayerName + " has no match document"})
+			return
+		}
+		matchOf[player.Id] = player.Match
+	}
+
+	// Start the matchday first if its deadline passed, so the XI decides earned points
+	if _, err = a.rolloverDueMatchday(ctx, request.AuctionID); err != nil {
+		a.logger.Error("failed to roll over matchday", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		scoring, err := a.auctionScoring(sessCtx, request.AuctionID)
+		if err != nil {
+			return nil, err
+		}
+		ruleset := scoring.Current()
+
+		points := make(map[primitive.ObjectID]int, len(request.Performances))
+		for _, performance := range request.Performances {
+			points[matchOf[performance.PlayerID]] = utils.FantasyPoints(ruleset.Rules, performance)
+		}
+
+		now := time.Now()
+		scorecardFilter := bson.M{"auction_id": request.AuctionID, "match_number": request.MatchNumber}
+		update := bson.M{
+			"$set": bson.M{
+				"performances": request.Performances,
+				"updated_at":   now,
+			},
+			"$setOnInsert": bson.M{
+				"_id":        primitive.NewObjectID(),
+				"created_at": now,
+			},
+		}
+		opts := options.Update().SetUpsert(true)
+		if _, err = a.MongoDBClient.Collection("scorecards").UpdateOne(sessCtx, scorecardFilter, update, opts); err != nil {
+			return nil, err
+		}
+
+		return nil, a.applyMatchPoints(sessCtx, request.AuctionID, request.MatchNumber, ruleset, points)
+	})
+	if err != nil {
+		a.logger.Error("failed to ingest scorecard", zap.Error(err), zap.Int("match_number", request.MatchNumber))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ingest scorecard"})
+		return
+	}
+
+	if err = a.clearLeaderboardCache(ctx, request.AuctionID); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after scorecard", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scorecard ingested successfully",
+		"players": len(request.Performances),
+	})
+}
+
+// GetScorecardsController returns the scorecards of an auction in match order
+func (a *API) GetScorecardsController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+		}
+		scorecards = []models.Scorecard{}
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get scorecards request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "match_number", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("scorecards").Find(ctx, bson.M{"auction_id": request.AuctionID}, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch scorecards", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &scorecards); err != nil {
+		a.logger.Error("failed to decode scorecards", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Scorecards fetched successfully",
+		"scorecards": scorecards,
+	})
+}
+
+// applyMatchPoints records the raw points of one match in the given match documents.
+// A first score counts as earned for players in the current playing XI and is scaled
+// by their current captaincy, a corrected score keeps the XI and captaincy it was
+// recorded with. Players of the auction left out of a corrected scorecard lose the
+// score they had for the match.
+func (a *API) applyMatchPoints(ctx context.Context, auctionID primitive.ObjectID, matchNumber int, ruleset models.ScoringRuleset, points map[primitive.ObjectID]int) error {
+	var matches, removed []models.Match
+
+	matchIDs := make([]primitive.ObjectID, 0, len(points))
+	for matchID := range points {
+		matchIDs = append(matchIDs, matchID)
+	}
+
+	cursor, err := a.MongoDBClient.Collection("matches").Find(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+	if err != nil {
+		return err
+	}
+	if err = cursor.All(ctx, &matches); err != nil {
+		return err
+	}
+	if len(matches) != len(matchIDs) {
+		return errors.New("match documents missing for scorecard players")
+	}
+
+	scored := append([]primitive.ObjectID{primitive.NilObjectID}, matchIDs...)
+	auctionMatches, err := a.MongoDBClient.Collection("players").Distinct(ctx, "match", bson.M{
+		"auction_id": auctionID,
+		"match":      bson.M{"$exists": true, "$nin": scored},
+	})
+	if err != nil {
+		return err
+	}
+	if len(auctionMatches) > 0 {
+		filter := bson.M{"_id": bson.M{"$in": auctionMatches}, "scores.match_number": matchNumber}
+		cursor, err = a.MongoDBClient.Collection("matches").Find(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if err = cursor.All(ctx, &removed); err != nil {
+			return err
+		}
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(matches)+len(removed))
+	for _, match := range matches {
+		earned, captaincy := match.CurrentX1, match.CurrentCaptaincy
+		if previous, ok := match.Score(matchNumber); ok {
+			earned, captaincy = previous.Earned, previous.Captaincy
+		}
+		match.SetScore(utils.ScoreMatch(ruleset.Rules, matchNumber, points[match.Id], earned, captaincy))
+		match.RulesVersion = ruleset.Version
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": match.Id}).SetReplacement(match))
+	}
+	for _, match := range removed {
+		match.RemoveScore(matchNumber)
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": match.Id}).SetReplacement(match))
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err = a.MongoDBClient.Collection("matches").BulkWrite(ctx, writes)
+	return err
+}
@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// errRulesChanged is returned when the ruleset was changed by another request meanwhile
+var errRulesChanged = errors.New("scoring rules changed concurrently")
+
+type scoringRulesRequest struct {
+	AuctionID primitive.ObjectID  `json:"auction_id" binding:"required"`
+	Rules     models.ScoringRules `json:"rules"`
+}
+
+// GetScoringRulesController returns the scoring rules in force and every earlier version
+func (a *API) GetScoringRulesController(c *gin.Context) {
+	var request struct {
+		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get scoring rules request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	scoring, err := a.auctionScoring(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to fetch scoring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if scoring.Versions == nil {
+		scoring.Versions = []models.ScoringRuleset{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Scoring rules fetched successfully",
+		"current":  scoring.Current(),
+		"versions": scoring.Versions,
+	})
+}
+
+// SaveScoringRulesController stores the rules as a new version and recalculates every
+// stored fantasy point of the auction under it
+func (a *API) SaveScoringRulesController(c *gin.Context) {
+	var request scoringRulesRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind save scoring rules request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if err := request.Rules.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpUpdate, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		scoring, err := a.auctionScoring(sessCtx, request.AuctionID)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		ruleset := models.ScoringRuleset{
+			Version:   scoring.Version + 1,
+			Rules:     request.Rules,
+			CreatedBy: c.GetString("email"),
+			CreatedAt: now,
+		}
+
+		// Matching the version read makes concurrent saves fail instead of sharing a version
+		filter := bson.M{"_id": request.AuctionID, "version": scoring.Version}
+		update := bson.M{
+			"$set":  bson.M{"version": ruleset.Version, "updated_at": now},
+			"$push": bson.M{"versions": ruleset},
+		}
+		if _, err = a.MongoDBClient.Collection("scoring_rules").UpdateOne(sessCtx, filter, update, options.Update().SetUpsert(true)); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, errRulesChanged
+			}
+			return nil, err
+		}
+
+		if err = a.recalculatePoints(sessCtx, request.AuctionID, ruleset); err != nil {
+			return nil, err
+		}
+		return ruleset, nil
+	})
+	if err != nil {
+		if errors.Is(err, errRulesChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Scoring rules were changed by someone else, reload and retry"})
+			return
+		}
+		a.logger.Error("failed to save scoring rules", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save scoring rules"})
+		return
+	}
+
+	if err = a.clearLeaderboardCache(ctx, request.AuctionID); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after rules change", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scoring rules saved and points recalculated",
+		"ruleset": result,
+	})
+}
+
+// RecalculatePointsController recalculates every stored fantasy point of the auction
+// under the rules in force
+func (a *API) RecalculatePointsController(c *gin.Context) {
+	var request struct {
+		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind recalculate points request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpScoreMatches, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		a.logger.Error("failed to start session", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		scoring, err := a.auctionScoring(sessCtx, request.AuctionID)
+		if err != nil {
+			return nil, err
+		}
+		ruleset := scoring.Current()
+		return ruleset.Version, a.recalculatePoints(sessCtx, request.AuctionID, ruleset)
+	})
+	if err != nil {
+		a.logger.Error("failed to recalculate points", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to recalculate points"})
+		return
+	}
+
+	if err = a.clearLeaderboardCache(ctx, request.AuctionID); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after recalculation", zap.Error(err))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Points recalculated successfully",
+		"rules_version": result,
+	})
+}
+
+// PreviewScoringController scores a sample scorecard under proposed rules without
+// storing anything, the default rules are used when none are given
+func (a *API) PreviewScoringController(c *gin.Context) {
+	var request struct {
+		Rules        *models.ScoringRules `json:"rules"`
+		Performances []models.Performance `json:"performances" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind scoring preview request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	rules := models.DefaultScoringRules()
+	if request.Rules != nil {
+		if err := request.Rules.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rules = *request.Rules
+	}
+
+	type previewScore struct {
+		models.Performance
+		Points utils.PointsBreakdown `json:"points"`
+	}
+	scores := make([]previewScore, 0, len(request.Performances))
+	for _, performance := range request.Performances {
+		if !performance.Valid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid performance in scorecard"})
+			return
+		}
+		scores = append(scores, previewScore{
+			Performance: performance,
+			Points:      utils.ScorePerformance(rules, performance),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scorecard previewed successfully",
+		"rules":   rules,
+		"scores":  scores,
+	})
+}
+
+// auctionScoring returns the scoring rules document of the auction, an empty one
+// using the default rules when the auction never set any
+func (a *API) auctionScoring(ctx context.Context, auctionID primitive.ObjectID) (models.AuctionScoring, error) {
+	var scoring models.AuctionScoring
+
+	err := a.MongoDBClient.Collection("scoring_rules").FindOne(ctx, bson.M{"_id": auctionID}).Decode(&scoring)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return models.AuctionScoring{AuctionID: auctionID}, nil
+	}
+	return scoring, err
+}
+
+// recalculatePoints rebuilds the scores of every match document of the auction from
+// the stored scorecards under the ruleset. Only the points change, whether a score
+// was earned and the captaincy it had are kept as they were recorded, so the result
+// depends on the inputs alone.
+func (a *API) recalculatePoints(ctx context.Context, auctionID primitive.ObjectID, ruleset models.ScoringRuleset) error {
+	var (
+		scorecards []models.Scorecard
+		players    []models.Player
+		matches    []models.Match
+	)
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "match_number", Value: 1}})
+	cursor, err := a.MongoDBClient.Collection("scorecards").Find(ctx, bson.M{"auction_id": auctionID}, findOptions)
+	if err != nil {
+		return err
+	}
+	if err = cursor.All(ctx, &scorecards); err != nil {
+		return err
+	}
+
+	playerFilter := bson.M{"auction_id": auctionID, "match": bson.M{"$exists": true, "$ne": primitive.NilObjectID}}
+	cursor, err = a.MongoDBClient.Collection("players").Find(ctx, playerFilter)
+	if err != nil {
+		return err
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		return err
+	}
+	if len(players) == 0 {
+		return nil
+	}
+
+	playerOf := make(map[primitive.ObjectID]primitive.ObjectID, len(players))
+	matchIDs := make([]primitive.ObjectID, 0, len(players))
+	for _, player := range players {
+		playerOf[player.Match] = player.Id
+		matchIDs = append(matchIDs, player.Match)
+	}
+
+	// points[player][i] is the score of the player in scorecards[i]
+	points := make(map[primitive.ObjectID]map[int]int)
+	for i, scorecard := range scorecards {
+		for _, performance := range scorecard.Performances {
+			if points[performance.PlayerID] == nil {
+				points[performance.PlayerID] = make(map[int]int)
+			}
+			points[performance.PlayerID][i] = utils.FantasyPoints(ruleset.Rules, performance)
+		}
+	}
+
+	cursor, err = a.MongoDBClient.Collection("matches").Find(ctx, bson.M{"_id": bson.M{"$in": matchIDs}})
+	if err != nil {
+		return err
+	}
+	if err = cursor.All(ctx, &matches); err != nil {
+		return err
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(matches))
+	for _, match := range matches {
+		recorded := make(map[int]models.MatchScore, len(match.Scores))
+		for _, score := range match.Scores {
+			recorded[score.MatchNumber] = score
+		}
+
+		playerPoints := points[playerOf[match.Id]]
+		match.Scores = nil
+		for i, scorecard := range scorecards {
+			if rawPoints, ok := playerPoints[i]; ok {
+				previous := recorded[scorecard.MatchNumber]
+				match.Scores = append(match.Scores, utils.ScoreMatch(ruleset.Rules, scorecard.MatchNumber, rawPoints, previous.Earned, previous.Captaincy))
+			}
+		}
+		match.RulesVersion = ruleset.Version
+		match.Recalculate()
+
+		writes = append(writes, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": match.Id}).SetReplacement(match))
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	_, err = a.MongoDBClient.Collection("matches").BulkWrite(ctx, writes)
+	return err
+}
@@ -57,13 +57,13 @@ func (a *API) SquadsController(c *gin.Context) {
 	}
 	for _, teamPlayer := range teamPlayers {
 		switch teamPlayer.Role {
-		case "Batter":
+		case models.RoleBatter:
 			response.Batters = append(response.Batters, teamPlayer)
-		case "Bowler":
+		case models.RoleBowler:
 			response.Bowlers = append(response.Bowlers, teamPlayer)
-		case "All-Rounder":
+		case models.RoleAllRounder:
 			response.AllRounders = append(response.AllRounders, teamPlayer)
-		case "Wicket-Keeper":
+		case models.RoleWicketKeeper:
 			response.WicketKeepers = append(response.WicketKeepers, teamPlayer)
 		default:
 			a.logger.Warn("unknown player role",
@@ -72,8 +72,15 @@ func (a *API) SquadsController(c *gin.Context) {
 		}
 	}
 
+	// Every player of the squad is checked, not only the ones picked in an XI
+	squad := make(map[primitive.ObjectID]bool, len(teamPlayers))
+	for _, teamPlayer := range teamPlayers {
+		squad[teamPlayer.Id] = true
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Squad fetched successfully",
-		"squad":   response,
+		"message":  "Squad fetched successfully",
+		"squad":    response,
+		"warnings": availabilityWarnings(teamPlayers, squad),
 	})
 }
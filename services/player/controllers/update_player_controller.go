@@ -3,8 +3,9 @@ package controllers
 import (
 	"auction-web/internal/constants"
 	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
 	"time"
 
@@ -32,16 +33,45 @@ func (a *API) UpdatePlayerController(c *gin.Context) {
 		return
 	}
 
+	// The player must belong to the auction the caller was authorized for
+	filter := bson.M{"_id": player.Id, "auction_id": player.AuctionId}
+
+	// The lifecycle is checked against the auction the stored player belongs to
+	var stored models.Player
+	if err := a.MongoDBClient.Collection("players").FindOne(ctx, filter).Decode(&stored); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			return
+		}
+		a.logger.Error("failed to find player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, playerUpdateOp(stored, player), stored.AuctionId); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", stored.AuctionId))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	// Fields owned by the server are kept from the stored player, a client can
+	// neither wipe them nor point the player at another match document
+	player.CatalogId = stored.CatalogId
+	player.Match = stored.Match
+	player.Availability = stored.Availability
+	player.ReplacementFor = stored.ReplacementFor
+	player.CreatedAt = stored.CreatedAt
+
 	// Set updated timestamp
 	player.UpdatedAt = time.Now()
 
-	filter := bson.M{"_id": player.Id}
 	replaceOptions := options.FindOneAndReplace().SetReturnDocument(options.After)
 
 	var updatedPlayer models.Player
 	err := a.MongoDBClient.Collection("players").FindOneAndReplace(ctx, filter, player, replaceOptions).Decode(&updatedPlayer)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
 			return
 		}
@@ -51,12 +81,11 @@ func (a *API) UpdatePlayerController(c *gin.Context) {
 	}
 
 	// Clear relevant cache entries
-	cachePattern := fmt.Sprintf("players:auction:%s", player.AuctionId.Hex())
-	keys, err := a.RedisClient.Keys(ctx, cachePattern).Result()
-	if err == nil && len(keys) > 0 {
-		if _, err = a.RedisClient.Del(ctx, keys...).Result(); err != nil {
-			a.logger.Warn("failed to clear player cache after update", zap.Error(err))
-		}
+	if err = a.clearPlayerCache(ctx, stored.AuctionId); err != nil {
+		a.logger.Warn("failed to clear player cache after update", zap.Error(err))
+	}
+	if err = a.clearLeaderboardCache(ctx, stored.AuctionId); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after update", zap.Error(err))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -64,3 +93,19 @@ func (a *API) UpdatePlayerController(c *gin.Context) {
 		"player":  updatedPlayer,
 	})
 }
+
+// playerUpdateOp returns the lifecycle operation performed by updating the stored player:
+// retaining it, selling it under the hammer, or editing its details
+func playerUpdateOp(stored, update models.Player) string {
+	switch {
+	case update.Hammer != stored.Hammer && (update.Hammer == models.HammerRetained || stored.Hammer == models.HammerRetained):
+		return models.AuctionOpRetainPlayers
+	case update.Hammer != stored.Hammer,
+		update.CurrentTeam != stored.CurrentTeam,
+		update.SellingPrice != stored.SellingPrice,
+		len(update.Bids) != len(stored.Bids):
+		return models.AuctionOpUpdatePlayer
+	default:
+		return models.AuctionOpManagePlayers
+	}
+}
@@ -0,0 +1,632 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"auction-web/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+type waiverClaimRequest struct {
+	AuctionID    primitive.ObjectID `json:"auction_id" binding:"required"`
+	TeamID       primitive.ObjectID `json:"team_id" binding:"required"`
+	PlayerID     primitive.ObjectID `json:"player_id"`
+	DropPlayerID primitive.ObjectID `json:"drop_player_id"`
+	Bid          float64            `json:"bid"`
+}
+
+// waiverResult is the outcome of processing the claims of a waiver window
+type waiverResult struct {
+	Processed int                  `json:"processed"`
+	Won       []models.WaiverClaim `json:"won"`
+	Lost      int                  `json:"lost"`
+	Invalid   int                  `json:"invalid"`
+}
+
+// SaveWaiverSettingsController sets the season-long free agent budget of every team
+// and opens a waiver window
+func (a *API) SaveWaiverSettingsController(c *gin.Context) {
+	var (
+		request struct {
+			AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+			Budget    float64            `json:"budget"`
+			OpensAt   time.Time          `json:"opens_at" binding:"required"`
+			ClosesAt  time.Time          `json:"closes_at" binding:"required"`
+		}
+		auction models.Auction
+		season  models.FantasySeason
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind waiver settings request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.Budget < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Budget cannot be negative"})
+		return
+	}
+	if !request.ClosesAt.After(request.OpensAt) || !request.ClosesAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Waiver window must close after it opens and in the future"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpWaivers, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	err := a.MongoDBClient.Collection("auctions").FindOne(ctx, bson.M{"_id": request.AuctionID}).Decode(&auction)
+	if err != nil {
+		a.logger.Error("failed to find auction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !auction.IsIPLAuction {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Waivers are only run for IPL auctions"})
+		return
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"waiver_budget":    request.Budget,
+			"waiver_opens_at":  request.OpensAt.UTC(),
+			"waiver_closes_at": request.ClosesAt.UTC(),
+			"updated_at":       time.Now(),
+		},
+		"$setOnInsert": bson.M{"matchday": 0},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err = a.MongoDBClient.Collection("fantasy_seasons").FindOneAndUpdate(ctx, bson.M{"_id": request.AuctionID}, update, opts).Decode(&season)
+	if err != nil {
+		a.logger.Error("failed to save waiver settings", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save waiver settings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Waiver settings saved successfully",
+		"season":  season,
+	})
+}
+
+// GetWaiverClaimsController returns the claims of a team and its remaining budget,
+// claims are blind so only the owners of the team can see them
+func (a *API) GetWaiverClaimsController(c *gin.Context) {
+	var (
+		request waiverClaimRequest
+		team    models.Team
+		claims  = []models.WaiverClaim{}
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind get waiver claims request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if !a.checkWaiverTeamOwner(ctx, c, request) {
+		return
+	}
+
+	season, err := a.fantasySeason(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to find fantasy season", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	if err = a.MongoDBClient.Collection("teams").FindOne(ctx, bson.M{"_id": request.TeamID}).Decode(&team); err != nil {
+		a.logger.Error("failed to find team", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := a.MongoDBClient.Collection("waiver_claims").Find(ctx, bson.M{"team_id": request.TeamID}, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch waiver claims", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &claims); err != nil {
+		a.logger.Error("failed to decode waiver claims", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Waiver claims fetched successfully",
+		"claims":    claims,
+		"budget":    season.WaiverBudget,
+		"spent":     team.WaiverSpent,
+		"remaining": season.WaiverBudget - team.WaiverSpent,
+		"opens_at":  season.WaiverOpensAt,
+		"closes_at": season.WaiverClosesAt,
+	})
+}
+
+// SubmitWaiverClaimController places a blind bid on a free agent during the
+// waiver window, optionally dropping a player of the squad if the claim wins. A team
+// bidding again on the same player replaces its claim.
+func (a *API) SubmitWaiverClaimController(c *gin.Context) {
+	var (
+		request waiverClaimRequest
+		team    models.Team
+		player  models.Player
+		claim   models.WaiverClaim
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind waiver claim request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if request.PlayerID.IsZero() || request.Bid < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player and a non-negative bid are required"})
+		return
+	}
+
+	if !a.checkWaiverTeamOwner(ctx, c, request) {
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpWaivers, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	season, err := a.fantasySeason(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to find fantasy season", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !season.WaiverOpen(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The waiver window is closed"})
+		return
+	}
+
+	if err = a.MongoDBClient.Collection("teams").FindOne(ctx, bson.M{"_id": request.TeamID}).Decode(&team); err != nil {
+		a.logger.Error("failed to find team", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if remaining := season.WaiverBudget - team.WaiverSpent; request.Bid > remaining {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Bid exceeds the remaining budget of %.2f", remaining)})
+		return
+	}
+
+	err = a.MongoDBClient.Collection("players").FindOne(ctx, bson.M{"_id": request.PlayerID, "auction_id": request.AuctionID}).Decode(&player)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Player not found in this auction"})
+			return
+		}
+		a.logger.Error("failed to find player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if !player.FreeAgent() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player " + player.PlayerName + " is not a free agent"})
+		return
+	}
+	switch player.AvailabilityStatus() {
+	case models.AvailabilityWithdrawn, models.AvailabilityReplaced:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player " + player.PlayerName + " is out of the season"})
+		return
+	}
+
+	if !request.DropPlayerID.IsZero() && !slices.Contains(team.Squad, request.DropPlayerID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dropped player is not in the squad"})
+		return
+	}
+
+	now := time.Now()
+	filter := bson.M{"team_id": request.TeamID, "player_id": request.PlayerID, "status": models.WaiverClaimPending}
+	set := bson.M{
+		"bid":          request.Bid,
+		"submitted_by": c.GetString("email"),
+		"updated_at":   now,
+	}
+	update := bson.M{
+		"$set": set,
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"auction_id": request.AuctionID,
+			"created_at": now,
+		},
+	}
+	if request.DropPlayerID.IsZero() {
+		update["$unset"] = bson.M{"drop_player_id": ""}
+	} else {
+		set["drop_player_id"] = request.DropPlayerID
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err = a.MongoDBClient.Collection("waiver_claims").FindOneAndUpdate(ctx, filter, update, opts).Decode(&claim); err != nil {
+		a.logger.Error("failed to save waiver claim", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save waiver claim"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Waiver claim saved successfully",
+		"claim":   claim,
+	})
+}
+
+// CancelWaiverClaimController withdraws a pending claim of a team
+func (a *API) CancelWaiverClaimController(c *gin.Context) {
+	var request waiverClaimRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind cancel waiver claim request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if !a.checkWaiverTeamOwner(ctx, c, request) {
+		return
+	}
+
+	filter := bson.M{"team_id": request.TeamID, "player_id": request.PlayerID, "status": models.WaiverClaimPending}
+	result, err := a.MongoDBClient.Collection("waiver_claims").DeleteOne(ctx, filter)
+	if err != nil {
+		a.logger.Error("failed to cancel waiver claim", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pending claim on this player"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Waiver claim cancelled successfully"})
+}
+
+// ProcessWaiversController processes the pending claims right away, without waiting
+// for the waiver window to close
+func (a *API) ProcessWaiversController(c *gin.Context) {
+	var request struct {
+		AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		a.logger.Error("failed to bind process waivers request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	if err := utils.CheckAuctionOperation(ctx, a.MongoDBClient, models.AuctionOpWaivers, request.AuctionID); err != nil {
+		a.logger.Warn("auction status check failed", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		status, message := utils.AuctionGuardResponse(err)
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	result, err := a.processWaivers(ctx, request.AuctionID)
+	if err != nil {
+		a.logger.Error("failed to process waivers", zap.Error(err), zap.Any("auction_id", request.AuctionID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process waivers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Waivers processed successfully",
+		"result":  result,
+	})
+}
+
+// RunWaiverProcessing periodically processes the claims of the waiver windows that closed
+func (a *API) RunWaiverProcessing(ctx context.Context) {
+	ticker := time.NewTicker(WaiverInterval)
+	defer ticker.Stop()
+
+	for {
+		a.processDueWaivers(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processDueWaivers runs a single waiver processing pass
+func (a *API) processDueWaivers(parent context.Context) {
+	var seasons []models.FantasySeason
+
+	ctx, cancel := context.WithTimeout(parent, constants.DBTimeout)
+	defer cancel()
+
+	cursor, err := a.MongoDBClient.Collection("fantasy_seasons").Find(ctx, bson.M{"waiver_closes_at": bson.M{"$lte": time.Now()}})
+	if err != nil {
+		a.logger.Error("failed to fetch due waivers", zap.Error(err))
+		return
+	}
+	if err = cursor.All(ctx, &seasons); err != nil {
+		a.logger.Error("failed to decode due waivers", zap.Error(err))
+		return
+	}
+
+	for _, season := range seasons {
+		jobCtx, jobCancel := context.WithTimeout(parent, constants.DBTimeout)
+		if _, err = a.processWaivers(jobCtx, season.AuctionID); err != nil {
+			a.logger.Error("failed to process waivers", zap.Error(err), zap.Any("auction_id", season.AuctionID))
+		}
+		jobCancel()
+	}
+}
+
+// processWaivers settles the pending claims of an auction and closes its waiver
+// window. Claims go by the highest bid, then by waiver priority, the team lowest
+// on the leaderboard first, then by the earliest claim. A claim loses once its
+// player is no longer a free agent and is invalid when the team can no longer
+// afford it or its dropped player already left the squad.
+func (a *API) processWaivers(ctx context.Context, auctionID primitive.ObjectID) (waiverResult, error) {
+	board, err := a.buildLeaderboard(ctx, auctionID)
+	if err != nil {
+		return waiverResult{}, err
+	}
+
+	session, err := a.MongoDBClient.Client().StartSession()
+	if err != nil {
+		return waiverResult{}, err
+	}
+	defer session.EndSession(ctx)
+
+	result, err := session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		var (
+			claims  []models.WaiverClaim
+			teams   []models.Team
+			players []models.Player
+			result  = waiverResult{Won: []models.WaiverClaim{}}
+		)
+
+		season, err := a.fantasySeason(sessCtx, auctionID)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor, err := a.MongoDBClient.Collection("waiver_claims").Find(sessCtx, bson.M{"auction_id": auctionID, "status": models.WaiverClaimPending})
+		if err != nil {
+			return nil, err
+		}
+		if err = cursor.All(sessCtx, &claims); err != nil {
+			return nil, err
+		}
+
+		cursor, err = a.MongoDBClient.Collection("teams").Find(sessCtx, bson.M{"auction_id": auctionID})
+		if err != nil {
+			return nil, err
+		}
+		if err = cursor.All(sessCtx, &teams); err != nil {
+			return nil, err
+		}
+		teamsByID := make(map[primitive.ObjectID]*models.Team, len(teams))
+		for i := range teams {
+			teamsByID[teams[i].ID] = &teams[i]
+		}
+
+		playerIDs := make([]primitive.ObjectID, 0, len(claims))
+		for _, claim := range claims {
+			playerIDs = append(playerIDs, claim.PlayerID)
+		}
+		cursor, err = a.MongoDBClient.Collection("players").Find(sessCtx, bson.M{"_id": bson.M{"$in": playerIDs}})
+		if err != nil {
+			return nil, err
+		}
+		if err = cursor.All(sessCtx, &players); err != nil {
+			return nil, err
+		}
+		owned := make(map[primitive.ObjectID]bool, len(players))
+		for _, player := range players {
+			owned[player.Id] = !player.FreeAgent()
+		}
+
+		orderWaiverClaims(claims, board.Teams)
+
+		now := time.Now()
+		for _, claim := range claims {
+			claim.ProcessedAt = &now
+			team, ok := teamsByID[claim.TeamID]
+			switch {
+			case !ok:
+				claim.Status, claim.Reason = models.WaiverClaimInvalid, "team no longer exists"
+			case owned[claim.PlayerID]:
+				claim.Status, claim.Reason = models.WaiverClaimLost, "player is no longer a free agent"
+			case claim.Bid > season.WaiverBudget-team.WaiverSpent:
+				claim.Status, claim.Reason = models.WaiverClaimInvalid, "bid exceeds the remaining budget"
+			case !claim.DropPlayerID.IsZero() && !slices.Contains(team.Squad, claim.DropPlayerID):
+				claim.Status, claim.Reason = models.WaiverClaimInvalid, "dropped player is no longer in the squad"
+			default:
+				if err = a.awardWaiverClaim(sessCtx, *team, claim); err != nil {
+					return nil, err
+				}
+				claim.Status = models.WaiverClaimWon
+				owned[claim.PlayerID] = true
+				team.WaiverSpent += claim.Bid
+				team.Squad = append(removeID(team.Squad, claim.DropPlayerID), claim.PlayerID)
+			}
+
+			switch claim.Status {
+			case models.WaiverClaimWon:
+				result.Won = append(result.Won, claim)
+			case models.WaiverClaimLost:
+				result.Lost++
+			default:
+				result.Invalid++
+			}
+			result.Processed++
+
+			update := bson.M{"$set": bson.M{
+				"status":       claim.Status,
+				"reason":       claim.Reason,
+				"processed_at": now,
+				"updated_at":   now,
+			}}
+			if _, err = a.MongoDBClient.Collection("waiver_claims").UpdateByID(sessCtx, claim.ID, update); err != nil {
+				return nil, err
+			}
+		}
+
+		seasonUpdate := bson.M{
+			"$unset": bson.M{"waiver_opens_at": "", "waiver_closes_at": ""},
+			"$set":   bson.M{"updated_at": now},
+		}
+		if _, err = a.MongoDBClient.Collection("fantasy_seasons").UpdateByID(sessCtx, auctionID, seasonUpdate); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+	if err != nil {
+		return waiverResult{}, err
+	}
+
+	if err = a.clearPlayerCache(ctx, auctionID); err != nil {
+		a.logger.Warn("failed to clear player cache after waivers", zap.Error(err))
+	}
+	if err = a.clearLeaderboardCache(ctx, auctionID); err != nil {
+		a.logger.Warn("failed to clear leaderboard cache after waivers", zap.Error(err))
+	}
+	if _, err = a.RedisClient.Del(ctx, fmt.Sprintf(TeamCacheKey, auctionID)).Result(); err != nil {
+		a.logger.Warn("failed to clear team cache after waivers", zap.Error(err))
+	}
+
+	return result.(waiverResult), nil
+}
+
+// orderWaiverClaims sorts the claims in the order they are settled: the highest bid
+// first, then the team lowest on the leaderboard, then the earliest claim
+func orderWaiverClaims(claims []models.WaiverClaim, standings []leaderboardEntry) {
+	// The leaderboard is ranked leader first, so teams further down get a higher priority
+	priority := make(map[primitive.ObjectID]int, len(standings))
+	for i, entry := range standings {
+		priority[entry.TeamID] = i + 1
+	}
+
+	sort.SliceStable(claims, func(i, j int) bool {
+		if claims[i].Bid != claims[j].Bid {
+			return claims[i].Bid > claims[j].Bid
+		}
+		if priority[claims[i].TeamID] != priority[claims[j].TeamID] {
+			return priority[claims[i].TeamID] > priority[claims[j].TeamID]
+		}
+		return claims[i].CreatedAt.Before(claims[j].CreatedAt)
+	})
+}
+
+// awardWaiverClaim moves the claimed player into the squad of the team, and the
+// dropped one out of it, and charges the bid to its budget
+func (a *API) awardWaiverClaim(ctx context.Context, team models.Team, claim models.WaiverClaim) error {
+	now := time.Now()
+	teams := a.MongoDBClient.Collection("teams")
+	players := a.MongoDBClient.Collection("players")
+
+	if !claim.DropPlayerID.IsZero() {
+		var dropped models.Player
+
+		if _, err := teams.UpdateByID(ctx, team.ID, bson.M{"$pull": bson.M{"squad": claim.DropPlayerID}}); err != nil {
+			return err
+		}
+		err := players.FindOneAndUpdate(ctx, bson.M{"_id": claim.DropPlayerID},
+			bson.M{"$set": bson.M{"current_team": "", "hammer": models.HammerReleased, "updated_at": now}}).Decode(&dropped)
+		if err != nil {
+			return err
+		}
+
+		// The dropped player cannot stay in the XI picked for the next matchday
+		if !dropped.Match.IsZero() {
+			update := bson.M{"$set": bson.M{"nextX1": false}, "$unset": bson.M{"nextCaptaincy": ""}}
+			if _, err = a.MongoDBClient.Collection("matches").UpdateByID(ctx, dropped.Match, update); err != nil {
+				return err
+			}
+		}
+	}
+
+	teamUpdate := bson.M{
+		"$push": bson.M{"squad": claim.PlayerID},
+		"$inc":  bson.M{"waiver_spent": claim.Bid},
+		"$set":  bson.M{"updated_at": now},
+	}
+	if _, err := teams.UpdateByID(ctx, team.ID, teamUpdate); err != nil {
+		return err
+	}
+
+	playerUpdate := bson.M{"$set": bson.M{"current_team": team.TeamName, "hammer": models.HammerSold, "updated_at": now}}
+	_, err := players.UpdateByID(ctx, claim.PlayerID, playerUpdate)
+	return err
+}
+
+// checkWaiverTeamOwner responds with an error unless the caller owns the team of the request
+func (a *API) checkWaiverTeamOwner(ctx context.Context, c *gin.Context, request waiverClaimRequest) bool {
+	err := a.checkTeamOwner(ctx, request.AuctionID, request.TeamID, c.GetString("email"))
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, errTeamNotOwned) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the owners of this team can manage its waiver claims"})
+		return false
+	}
+	a.logger.Error("failed to check team owner", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+	return false
+}
+
+// fantasySeason returns the season of the auction, a blank one when none was started
+func (a *API) fantasySeason(ctx context.Context, auctionID primitive.ObjectID) (models.FantasySeason, error) {
+	season := models.FantasySeason{AuctionID: auctionID}
+
+	err := a.MongoDBClient.Collection("fantasy_seasons").FindOne(ctx, bson.M{"_id": auctionID}).Decode(&season)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return season, err
+	}
+	return season, nil
+}
+
+// removeID returns the list without the id
+func removeID(ids []primitive.ObjectID, id primitive.ObjectID) []primitive.ObjectID {
+	kept := make([]primitive.ObjectID, 0, len(ids))
+	for _, candidate := range ids {
+		if candidate != id {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"auction-web/pkg/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOrderWaiverClaims(t *testing.T) {
+	leader, middle, last, gone := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	standings := []leaderboardEntry{{TeamID: leader, Rank: 1}, {TeamID: middle, Rank: 2}, {TeamID: last, Rank: 3}}
+
+	start := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+	claim := func(teamID primitive.ObjectID, bid float64, minute int) models.WaiverClaim {
+		return models.WaiverClaim{TeamID: teamID, Bid: bid, CreatedAt: start.Add(time.Duration(minute) * time.Minute)}
+	}
+
+	tests := []struct {
+		name   string
+		claims []models.WaiverClaim
+		want   []int // minutes the claims were made at, in settling order
+	}{
+		{
+			name:   "highest bid wins regardless of rank",
+			claims: []models.WaiverClaim{claim(last, 10, 0), claim(leader, 25, 1), claim(middle, 15, 2)},
+			want:   []int{1, 2, 0},
+		},
+		{
+			name:   "lowest team on the leaderboard goes first on a tied bid",
+			claims: []models.WaiverClaim{claim(leader, 20, 0), claim(middle, 20, 1), claim(last, 20, 2)},
+			want:   []int{2, 1, 0},
+		},
+		{
+			name:   "earliest claim breaks a tie between claims of a team",
+			claims: []models.WaiverClaim{claim(middle, 20, 5), claim(middle, 20, 1), claim(leader, 20, 0)},
+			want:   []int{1, 5, 0},
+		},
+		{
+			name:   "teams off the leaderboard come last on a tied bid",
+			claims: []models.WaiverClaim{claim(gone, 20, 0), claim(leader, 20, 1)},
+			want:   []int{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderWaiverClaims(tt.claims, standings)
+
+			got := make([]int, 0, len(tt.claims))
+			for _, claim := range tt.claims {
+				got = append(got, int(claim.CreatedAt.Sub(start).Minutes()))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orderWaiverClaims() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package controllers
+
+import (
+	"auction-web/internal/constants"
+	"auction-web/pkg/models"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// errTeamNotOwned is returned when the caller is not an owner of the team
+var errTeamNotOwned = errors.New("caller is not an owner of the team")
+
+type scoutingRequest struct {
+	AuctionID primitive.ObjectID `json:"auction_id" binding:"required"`
+	TeamID    primitive.ObjectID `json:"team_id" binding:"required"`
+	PlayerID  primitive.ObjectID `json:"player_id"`
+	Priority  string             `json:"priority"`
+	MaxPrice  float64            `json:"max_price"`
+	Note      string             `json:"note"`
+	Lots      int64              `json:"lots"`
+}
+
+// watchlistPlayer is a watchlist entry along with the player it shortlists
+type watchlistPlayer struct {
+	models.WatchlistEntry
+	Player   models.Player `json:"player"`
+	LotsAway int64         `json:"lots_away,omitempty"`
+}
+
+// GetWatchlistController returns the watchlist of a team, highest priority first
+func (a *API) GetWatchlistController(c *gin.Context) {
+	var (
+		request scoutingRequest
+		entries []models.WatchlistEntry
+		players []models.Player
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if !a.bindScoutingRequest(ctx, c, &request) {
+		return
+	}
+
+	cursor, err := a.MongoDBClient.Collection("watchlists").Find(ctx, bson.M{"team_id": request.TeamID})
+	if err != nil {
+		a.logger.Error("failed to fetch watchlist", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &entries); err != nil {
+		a.logger.Error("failed to decode watchlist", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	playerIDs := make([]primitive.ObjectID, 0, len(entries))
+	for _, entry := range entries {
+		playerIDs = append(playerIDs, entry.PlayerID)
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "player_number", Value: 1}})
+	cursor, err = a.MongoDBClient.Collection("players").Find(ctx, bson.M{"_id": bson.M{"$in": playerIDs}}, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch watchlisted players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &players); err != nil {
+		a.logger.Error("failed to decode watchlisted players", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	entriesByPlayer := make(map[primitive.ObjectID]models.WatchlistEntry, len(entries))
+	for _, entry := range entries {
+		entriesByPlayer[entry.PlayerID] = entry
+	}
+
+	// Group by priority, keeping the lot order within each tag
+	watchlist := []watchlistPlayer{}
+	for _, priority := range []string{models.WatchPriorityHigh, models.WatchPriorityMedium, models.WatchPriorityLow} {
+		for _, player := range players {
+			if entry := entriesByPlayer[player.Id]; entry.Priority == priority {
+				watchlist = append(watchlist, watchlistPlayer{WatchlistEntry: entry, Player: player})
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Watchlist fetched successfully",
+		"watchlist": watchlist,
+	})
+}
+
+// SaveWatchlistEntryController adds a player to the watchlist of a team, or updates
+// the priority and maximum price of an entry already there
+func (a *API) SaveWatchlistEntryController(c *gin.Context) {
+	var (
+		request  scoutingRequest
+		response models.WatchlistEntry
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if !a.bindScoutingRequest(ctx, c, &request) {
+		return
+	}
+
+	if request.Priority == "" {
+		request.Priority = models.WatchPriorityMedium
+	}
+	if !models.ValidWatchPriority(request.Priority) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid priority"})
+		return
+	}
+	if request.MaxPrice < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid max price"})
+		return
+	}
+	if !a.checkScoutedPlayer(ctx, c, request) {
+		return
+	}
+
+	now := time.Now()
+	filter := bson.M{"team_id": request.TeamID, "player_id": request.PlayerID}
+	update := bson.M{
+		"$set": bson.M{
+			"priority":   request.Priority,
+			"max_price":  request.MaxPrice,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"auction_id": request.AuctionID,
+			"created_at": now,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := a.MongoDBClient.Collection("watchlists").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
+	if err != nil {
+		a.logger.Error("failed to save watchlist entry", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save watchlist entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Watchlist updated successfully",
+		"entry":   response,
+	})
+}
+
+// RemoveWatchlistEntryController removes a player from the watchlist of a team
+func (a *API) RemoveWatchlistEntryController(c *gin.Context) {
+	var request scoutingRequest
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if !a.bindScoutingRequest(ctx, c, &request) {
+		return
+	}
+
+	filter := bson.M{"team_id": request.TeamID, "player_id": request.PlayerID}
+	result, err := a.MongoDBClient.Collection("watchlists").DeleteOne(ctx, filter)
+	if err != nil {
+		a.logger.Error("failed to remove watchlist entry", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove watchlist entry"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player is not on the watchlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Player removed from watchlist",
+	})
+}
+
+// UpcomingWatchlistController returns the watchlisted players among the next lots
+// of the auction, the lot queue being the upcoming players in player number order
+func (a *API) UpcomingWatchlistController(c *gin.Context) {
+	var (
+		request  scoutingRequest
+		upcoming []models.Player
+		entries  []models.WatchlistEntry
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if !a.bindScoutingRequest(ctx, c, &request) {
+		return
+	}
+	if request.Lots <= 0 {
+		request.Lots = UpcomingLots
+	}
+	if request.Lots > MaxPlayerPageSize {
+		request.Lots = MaxPlayerPageSize
+	}
+
+	queueFilter := bson.M{"auction_id": request.AuctionID, "hammer": "upcoming"}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "player_number", Value: 1}}).
+		SetLimit(request.Lots)
+	cursor, err := a.MongoDBClient.Collection("players").Find(ctx, queueFilter, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch lot queue", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &upcoming); err != nil {
+		a.logger.Error("failed to decode lot queue", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	playerIDs := make([]primitive.ObjectID, 0, len(upcoming))
+	for _, player := range upcoming {
+		playerIDs = append(playerIDs, player.Id)
+	}
+	watchFilter := bson.M{"team_id": request.TeamID, "player_id": bson.M{"$in": playerIDs}}
+	cursor, err = a.MongoDBClient.Collection("watchlists").Find(ctx, watchFilter)
+	if err != nil {
+		a.logger.Error("failed to fetch watchlist", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &entries); err != nil {
+		a.logger.Error("failed to decode watchlist", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+
+	entriesByPlayer := make(map[primitive.ObjectID]models.WatchlistEntry, len(entries))
+	for _, entry := range entries {
+		entriesByPlayer[entry.PlayerID] = entry
+	}
+
+	// lots_away is 1 for the next player under the hammer
+	watchlist := []watchlistPlayer{}
+	for i, player := range upcoming {
+		if entry, ok := entriesByPlayer[player.Id]; ok {
+			watchlist = append(watchlist, watchlistPlayer{
+				WatchlistEntry: entry,
+				Player:         player,
+				LotsAway:       int64(i + 1),
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Upcoming watchlisted players fetched successfully",
+		"lots":      request.Lots,
+		"watchlist": watchlist,
+	})
+}
+
+// GetPlayerNotesController returns the private notes of a team, or its note on one player
+func (a *API) GetPlayerNotesController(c *gin.Context) {
+	var (
+		request scoutingRequest
+		notes   []models.PlayerNote
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if !a.bindScoutingRequest(ctx, c, &request) {
+		return
+	}
+
+	filter := bson.M{"team_id": request.TeamID}
+	if !request.PlayerID.IsZero() {
+		filter["player_id"] = request.PlayerID
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	cursor, err := a.MongoDBClient.Collection("player_notes").Find(ctx, filter, findOptions)
+	if err != nil {
+		a.logger.Error("failed to fetch player notes", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return
+	}
+	if err = cursor.All(ctx, &notes); err != nil {
+		a.logger.Error("failed to decode player notes", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error while decoding"})
+		return
+	}
+	if notes == nil {
+		notes = []models.PlayerNote{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notes fetched successfully",
+		"notes":   notes,
+	})
+}
+
+// SavePlayerNoteController writes the private note of a team on a player, an empty note deletes it
+func (a *API) SavePlayerNoteController(c *gin.Context) {
+	var (
+		request  scoutingRequest
+		response models.PlayerNote
+	)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), constants.DBTimeout)
+	defer cancel()
+
+	if !a.bindScoutingRequest(ctx, c, &request) {
+		return
+	}
+	if !a.checkScoutedPlayer(ctx, c, request) {
+		return
+	}
+
+	filter := bson.M{"team_id": request.TeamID, "player_id": request.PlayerID}
+	request.Note = strings.TrimSpace(request.Note)
+	if request.Note == "" {
+		if _, err := a.MongoDBClient.Collection("player_notes").DeleteOne(ctx, filter); err != nil {
+			a.logger.Error("failed to delete player note", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete note"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Note deleted successfully",
+		})
+		return
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"note":       request.Note,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"auction_id": request.AuctionID,
+			"created_at": now,
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := a.MongoDBClient.Collection("player_notes").FindOneAndUpdate(ctx, filter, update, opts).Decode(&response)
+	if err != nil {
+		a.logger.Error("failed to save player note", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save note"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note saved successfully",
+		"note":    response,
+	})
+}
+
+// bindScoutingRequest binds the request and checks the caller owns the team, it
+// writes the error response and returns false when the request cannot go on
+func (a *API) bindScoutingRequest(ctx context.Context, c *gin.Context, request *scoutingRequest) bool {
+	if err := c.ShouldBindJSON(request); err != nil {
+		a.logger.Error("failed to bind scouting request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return false
+	}
+
+	if err := a.checkTeamOwner(ctx, request.AuctionID, request.TeamID, c.GetString("email")); err != nil {
+		if errors.Is(err, errTeamNotOwned) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the owners of this team can access its watchlist and notes"})
+			return false
+		}
+		a.logger.Error("failed to check team owner", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return false
+	}
+	return true
+}
+
+// checkScoutedPlayer checks the player of the request belongs to its auction
+func (a *API) checkScoutedPlayer(ctx context.Context, c *gin.Context, request scoutingRequest) bool {
+	if request.PlayerID.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player id is required"})
+		return false
+	}
+
+	count, err := a.MongoDBClient.Collection("players").CountDocuments(ctx, bson.M{
+		"_id":        request.PlayerID,
+		"auction_id": request.AuctionID,
+	})
+	if err != nil {
+		a.logger.Error("failed to find player", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error from db"})
+		return false
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player not found in this auction"})
+		return false
+	}
+	return true
+}
+
+// checkTeamOwner returns errTeamNotOwned unless the email is an owner of the team
+func (a *API) checkTeamOwner(ctx context.Context, auctionID, teamID primitive.ObjectID, email string) error {
+	count, err := a.MongoDBClient.Collection("teams").CountDocuments(ctx, bson.M{
+		"_id":         teamID,
+		"auction_id":  auctionID,
+		"team_owners": email,
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errTeamNotOwned
+	}
+	return nil
+}
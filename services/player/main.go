@@ -7,7 +7,12 @@ import (
 	"auction-web/pkg/utils"
 	"auction-web/services/player/controllers"
 	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	_ "time/tzdata" // fixture timezones are loaded on images without zoneinfo
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
 
@@ -25,7 +30,66 @@ func main() {
 	}
 	defer api.MongoDBClient.Client().Disconnect(ctx)
 	defer api.RedisClient.Close()
+
+	// `player-service consistency [-auction id] [-repair]` runs the checker and exits
+	if len(os.Args) > 1 && os.Args[1] == "consistency" {
+		if !runConsistency(api, os.Args[2:]) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	api.RegisterRoutes(router)
 
+	// Matchdays whose XI deadline passed are closed in the background
+	rolloverCtx, stopRollover := context.WithCancel(context.Background())
+	defer stopRollover()
+	go api.RunMatchdayRollover(rolloverCtx)
+
+	// Claims of the waiver windows that closed are processed in the background
+	waiverCtx, stopWaivers := context.WithCancel(context.Background())
+	defer stopWaivers()
+	go api.RunWaiverProcessing(waiverCtx)
+
 	utils.StartServer(ctx, router, "player", "7004")
 }
+
+// runConsistency checks the match documents and squads of one auction, or of every
+// auction without -auction, prints the report as JSON and reports whether it succeeded
+func runConsistency(api *controllers.API, args []string) bool {
+	auctionLogger := logger.Get()
+
+	flags := flag.NewFlagSet("consistency", flag.ContinueOnError)
+	auction := flags.String("auction", "", "id of the auction to check, every auction when empty")
+	repair := flags.Bool("repair", false, "repair the inconsistencies found")
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+
+	auctionID := primitive.NilObjectID
+	if *auction != "" {
+		id, err := primitive.ObjectIDFromHex(*auction)
+		if err != nil {
+			auctionLogger.Error("invalid auction id", zap.Error(err))
+			return false
+		}
+		auctionID = id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), controllers.ConsistencyTimeout)
+	defer cancel()
+
+	report, err := api.CheckConsistency(ctx, auctionID, *repair)
+	if err != nil {
+		auctionLogger.Error("failed to check consistency", zap.Error(err))
+		return false
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		auctionLogger.Error("failed to print consistency report", zap.Error(err))
+		return false
+	}
+	return true
+}